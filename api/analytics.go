@@ -0,0 +1,94 @@
+package main
+
+import (
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Analytics struct {
+	From                 string                  `json:"from"`
+	To                   string                  `json:"to"`
+	GroupBy              string                  `json:"groupBy"`
+	Summary              models.AnalyticsSummary `json:"summary"`
+	NewPatients          []models.PeriodCount    `json:"newPatients"`
+	AppointmentsByStatus []gin.H                 `json:"appointmentsByStatus"`
+	AppointmentsByDoctor []gin.H                 `json:"appointmentsByDoctor"`
+	MetricsByType        []gin.H                 `json:"metricsByType"`
+	BlogsByAuthor        []gin.H                 `json:"blogsByAuthor"`
+	UsersByRole          []gin.H                 `json:"usersByRole"`
+}
+
+// groupings are the accepted values of the groupBy query parameter
+var groupings = []string{"day", "week", "month", "year"}
+
+const dateLayout = "2006-01-02"
+
+// recentPatientDays is the window counted as "recent" in the summary
+const recentPatientDays = 30
+
+// labelled converts store counts into objects keyed by labelKey
+func labelled(labelKey string, counts []models.LabelCount) []gin.H {
+	results := []gin.H{}
+	for _, lc := range counts {
+		results = append(results, gin.H{labelKey: lc.Label, "count": lc.Count})
+	}
+	return results
+}
+
+func (s *server) getAnalytics(c *gin.Context) {
+	q := listQuery{c: c}
+	groupBy := q.text("groupBy")
+	if groupBy == "" {
+		groupBy = "month"
+	} else if !contains(groupings, groupBy) {
+		q.invalid("groupBy", "must be one of "+strings.Join(groupings, ", "))
+	}
+
+	// Default to the twelve months up to today; "to" is inclusive
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	invalid := len(q.details)
+	from, to := q.date("from"), q.date("to")
+	if from.IsZero() {
+		from = today.AddDate(-1, 0, 0)
+	}
+	if to.IsZero() {
+		to = today
+	}
+	// Only compare the dates if both parsed
+	if len(q.details) == invalid && to.Before(from) {
+		q.invalid("to", "must not be before from")
+	}
+	if !q.ok() {
+		return
+	}
+
+	result, err := s.store.Analytics.Analytics(c.Request.Context(), store.AnalyticsQuery{
+		From:        from,
+		To:          to.AddDate(0, 0, 1),
+		GroupBy:     groupBy,
+		RecentSince: now.AddDate(0, 0, -recentPatientDays),
+	})
+	if err != nil {
+		respondStoreError(c, err, "Analytics")
+		return
+	}
+
+	c.JSON(http.StatusOK, Analytics{
+		From:                 from.Format(dateLayout),
+		To:                   to.Format(dateLayout),
+		GroupBy:              groupBy,
+		Summary:              result.Summary,
+		NewPatients:          result.NewPatients,
+		AppointmentsByStatus: labelled("status", result.AppointmentsByStatus),
+		AppointmentsByDoctor: labelled("doctor", result.AppointmentsByDoctor),
+		MetricsByType:        labelled("type", result.MetricsByType),
+		BlogsByAuthor:        labelled("author", result.BlogsByAuthor),
+		UsersByRole:          labelled("role", result.UsersByRole),
+	})
+}
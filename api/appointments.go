@@ -0,0 +1,187 @@
+package main
+
+import (
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type appointmentRequest struct {
+	PatientID   int    `json:"patientId" binding:"gt=0"`
+	DateTime    string `json:"dateTime" binding:"required"` // Accept as string initially
+	Description string `json:"description" binding:"max=1000"`
+	Status      string `json:"status" binding:"oneof=Scheduled Completed Cancelled Rescheduled"`
+	Doctor      string `json:"doctor" binding:"notblank,max=255"`
+}
+
+// parseAppointmentTime accepts datetime-local values with or without seconds as well as RFC 3339
+func parseAppointmentTime(value string) (time.Time, error) {
+	dateTime, err := time.Parse("2006-01-02T15:04", value)
+	if err != nil {
+		// If that fails, try with seconds
+		dateTime, err = time.Parse("2006-01-02T15:04:05", value)
+		if err != nil {
+			// If that fails too, try RFC3339 format
+			dateTime, err = time.Parse(time.RFC3339, value)
+		}
+	}
+	return dateTime, err
+}
+
+// bindAppointment reads the request body into an appointment, writing an
+// error response and returning false if it is invalid. The caller checks the
+// time against the appointment slots.
+func (s *server) bindAppointment(c *gin.Context, appointment *models.Appointment) bool {
+	var appointmentData appointmentRequest
+	if !bindJSON(c, &appointmentData) {
+		return false
+	}
+
+	dateTime, err := parseAppointmentTime(appointmentData.DateTime)
+	if err != nil {
+		respondInvalid(c, "Invalid appointment", fieldError{Field: "dateTime", Message: "must be a date and time such as 2024-06-11T09:30"})
+		return false
+	}
+
+	appointment.PatientID = appointmentData.PatientID
+	appointment.DateTime = dateTime
+	appointment.Description = appointmentData.Description
+	appointment.Status = appointmentData.Status
+	appointment.Doctor = appointmentData.Doctor
+	return true
+}
+
+// respondAppointmentError maps a failed write, pointing at patientId when it
+// names a patient that does not exist
+func respondAppointmentError(c *gin.Context, err error) {
+	if errors.Is(err, store.ErrInvalidReference) {
+		respondInvalidReference(c, "patientId", "does not match an existing patient")
+		return
+	}
+	respondStoreError(c, err, "Appointment")
+}
+
+// Appointment Handlers
+func (s *server) getAppointments(c *gin.Context) {
+	q := listQuery{c: c}
+	filter := store.AppointmentFilter{
+		PatientID: q.id("patientId"),
+		Status:    q.text("status"),
+		Doctor:    q.text("doctor"),
+		From:      q.date("from"),
+		To:        q.date("to"),
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		q.invalid("to", "must not be before from")
+	}
+	page := q.page(store.AppointmentSortKeys)
+	if !q.ok() {
+		return
+	}
+	// "to" is inclusive, so the range ends at the start of the next day
+	if !filter.To.IsZero() {
+		filter.To = filter.To.AddDate(0, 0, 1)
+	}
+
+	appointments, total, err := s.store.Appointments.List(c.Request.Context(), filter, page)
+	if err != nil {
+		respondStoreError(c, err, "Appointment")
+		return
+	}
+
+	respondPage(c, page, total, appointments)
+}
+
+func (s *server) getAppointment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, codeInvalidRequest, "Invalid ID format")
+		return
+	}
+
+	appointment, err := s.store.Appointments.Get(c.Request.Context(), id)
+	if err != nil {
+		respondStoreError(c, err, "Appointment")
+		return
+	}
+
+	c.JSON(http.StatusOK, appointment)
+}
+
+// checkSlot writes a 422 and returns false if t does not start on a slot
+// boundary
+func (s *server) checkSlot(c *gin.Context, t time.Time) bool {
+	if msg := s.checkAppointmentSlot(c.Request.Context(), t); msg != "" {
+		respondInvalid(c, "Invalid appointment", fieldError{Field: "dateTime", Message: msg})
+		return false
+	}
+	return true
+}
+
+func (s *server) createAppointment(c *gin.Context) {
+	var newAppointment models.Appointment
+	if !s.bindAppointment(c, &newAppointment) || !s.checkSlot(c, newAppointment.DateTime) {
+		return
+	}
+
+	if err := s.store.Appointments.Create(c.Request.Context(), &newAppointment); err != nil {
+		respondAppointmentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newAppointment)
+}
+
+func (s *server) updateAppointment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, codeInvalidRequest, "Invalid ID format")
+		return
+	}
+
+	updatedAppointment := models.Appointment{ID: id}
+	if !s.bindAppointment(c, &updatedAppointment) {
+		return
+	}
+
+	current, err := s.store.Appointments.Get(c.Request.Context(), id)
+	if err != nil {
+		respondStoreError(c, err, "Appointment")
+		return
+	}
+	// Only a new time has to fit the slots, so appointments booked before the
+	// slot length changed can still be edited. The form sends times to the
+	// minute, which counts as unchanged.
+	if updatedAppointment.DateTime.Truncate(time.Minute).Equal(current.DateTime.Truncate(time.Minute)) {
+		updatedAppointment.DateTime = current.DateTime
+	} else if !s.checkSlot(c, updatedAppointment.DateTime) {
+		return
+	}
+
+	if err := s.store.Appointments.Update(c.Request.Context(), &updatedAppointment); err != nil {
+		respondAppointmentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedAppointment)
+}
+
+func (s *server) deleteAppointment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, codeInvalidRequest, "Invalid ID format")
+		return
+	}
+
+	if err := s.store.Appointments.Delete(c.Request.Context(), id); err != nil {
+		respondStoreError(c, err, "Appointment")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Appointment deleted"})
+}
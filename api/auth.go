@@ -0,0 +1,241 @@
+package main
+
+import (
+	"carehub-microservice/config"
+	"carehub-microservice/logging"
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/exp/slog"
+)
+
+// Context keys set by authMiddleware for downstream handlers
+const (
+	ctxUserID   = "userID"
+	ctxUserRole = "userRole"
+	ctxUserName = "userName"
+)
+
+const tokenIssuer = "carehub"
+
+// Claims carried inside every access token
+type Claims struct {
+	UserID int    `json:"uid"`
+	Role   string `json:"role"`
+	Name   string `json:"name"`
+	jwt.RegisteredClaims
+}
+
+// loadJWTSecret returns the HMAC key used to sign and verify access tokens.
+// Without one a random key is generated, which means tokens do not survive a
+// restart.
+func loadJWTSecret(cfg config.AuthConfig) ([]byte, error) {
+	if cfg.JWTSecret != "" {
+		return []byte(cfg.JWTSecret), nil
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate JWT secret: %v", err)
+	}
+	slog.Warn("No JWT secret configured, using a random signing key; tokens will be invalidated on restart")
+	return secret, nil
+}
+
+func (s *server) generateToken(ctx context.Context, user models.User) (string, time.Time, error) {
+	// Token lifetime follows the session timeout in the system settings
+	now := time.Now()
+	expiresAt := now.Add(time.Duration(s.currentSettings(ctx).SessionTimeoutMinutes) * time.Minute)
+
+	claims := Claims{
+		UserID: user.ID,
+		Role:   user.Role,
+		Name:   user.Name,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    tokenIssuer,
+			Subject:   strconv.Itoa(user.ID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.jwtSecret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+func (s *server) parseToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		return s.jwtSecret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(tokenIssuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// authMiddleware rejects requests without a valid Bearer token or whose
+// account no longer exists, and stores the caller's current identity and role
+// in the context.
+func (s *server) authMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		tokenString := strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+		if !strings.HasPrefix(header, "Bearer ") || tokenString == "" {
+			respondError(c, http.StatusUnauthorized, codeUnauthorized, "Missing authorization token")
+			return
+		}
+
+		claims, err := s.parseToken(tokenString)
+		if err != nil {
+			if errors.Is(err, jwt.ErrTokenExpired) {
+				respondError(c, http.StatusUnauthorized, codeTokenExpired, "Token expired")
+				return
+			}
+			respondError(c, http.StatusUnauthorized, codeUnauthorized, "Invalid token")
+			return
+		}
+
+		// The account is looked up on every request so that deleting a user or
+		// changing their role takes effect before the token expires
+		user, err := s.store.Users.Get(c.Request.Context(), claims.UserID)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				respondError(c, http.StatusUnauthorized, codeUnauthorized, "Account no longer exists")
+				return
+			}
+			respondStoreError(c, err, "User")
+			return
+		}
+
+		c.Set(ctxUserID, user.ID)
+		c.Set(ctxUserRole, user.Role)
+		c.Set(ctxUserName, user.Name)
+		c.Next()
+	}
+}
+
+// Authentication Handlers
+func (s *server) login(c *gin.Context) {
+	var loginData struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+
+	if !bindJSON(c, &loginData) {
+		return
+	}
+
+	user, err := s.store.Users.GetByUsername(c.Request.Context(), loginData.Username)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			burnPasswordCheck(loginData.Password)
+			respondError(c, http.StatusUnauthorized, codeInvalidCredentials, "Invalid credentials")
+			return
+		}
+		respondStoreError(c, err, "User")
+		return
+	}
+
+	ok, needsRehash := verifyPassword(user.Password, loginData.Password)
+	if ok {
+		// Upgrade legacy plaintext or weak hashes now that we know the password
+		if needsRehash {
+			if hash, err := hashPassword(loginData.Password); err != nil {
+				logging.FromContext(c.Request.Context()).Warn("Failed to hash password", "user_id", user.ID, "error", err)
+			} else if err := s.store.Users.SetPassword(c.Request.Context(), user.ID, user.Password, hash); err != nil {
+				logging.FromContext(c.Request.Context()).Warn("Failed to upgrade password hash", "user_id", user.ID, "error", err)
+			}
+		}
+
+		token, expiresAt, err := s.generateToken(c.Request.Context(), user)
+		if err != nil {
+			respondInternalError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"token":     token,
+			"expiresAt": expiresAt,
+			"user": gin.H{
+				"id":   user.ID,
+				"name": user.Name,
+				"role": user.Role,
+			},
+		})
+		return
+	}
+
+	respondError(c, http.StatusUnauthorized, codeInvalidCredentials, "Invalid credentials")
+}
+
+// signup creates a patient account. It is public, so the role cannot be
+// chosen; every other account is created by an admin through createUser.
+func (s *server) signup(c *gin.Context) {
+	var userData userRequest
+	if !bindJSON(c, &userData) {
+		return
+	}
+
+	if missing := userData.missingFields(true); len(missing) > 0 {
+		respondInvalid(c, "Username, password, name and email are required", missing...)
+		return
+	}
+	if userData.Role != "" && userData.Role != RolePatient {
+		respondInvalid(c, "Invalid role", fieldError{Field: "role", Message: "cannot be chosen at signup"})
+		return
+	}
+	userData.Role = RolePatient
+
+	// Check if username or email already exists
+	conflict, err := s.findUserConflict(c.Request.Context(), userData.Username, userData.Email, 0)
+	if err != nil {
+		respondStoreError(c, err, "User")
+		return
+	}
+	if conflict != "" {
+		respondConflict(c, "user", conflict)
+		return
+	}
+
+	if msg := s.checkPasswordPolicy(c.Request.Context(), userData.Password); msg != "" {
+		respondInvalid(c, msg, fieldError{Field: "password", Message: msg})
+		return
+	}
+
+	passwordHash, err := hashPassword(userData.Password)
+	if err != nil {
+		respondInvalid(c, "Invalid password", fieldError{Field: "password", Message: "cannot be longer than 72 bytes"})
+		return
+	}
+
+	user := userData.toUser()
+	user.Password = passwordHash
+	if err := s.store.Users.Create(c.Request.Context(), &user); err != nil {
+		respondStoreError(c, err, "User")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "User created successfully",
+		"userId":  user.ID,
+	})
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Roles as stored in the users table
+const (
+	RoleSuperAdmin = "superadmin"
+	RoleAdmin      = "admin"
+	RoleDoctor     = "doctor"
+	RoleNurse      = "nurse"
+	RoleIntern     = "intern"
+	RolePatient    = "patient"
+)
+
+// Common role sets used by the policy table
+var (
+	adminRoles    = []string{RoleSuperAdmin, RoleAdmin}
+	clinicalRoles = []string{RoleSuperAdmin, RoleAdmin, RoleDoctor, RoleNurse}
+	staffRoles    = []string{RoleSuperAdmin, RoleAdmin, RoleDoctor, RoleNurse, RoleIntern}
+	allRoles      = []string{RoleSuperAdmin, RoleAdmin, RoleDoctor, RoleNurse, RoleIntern, RolePatient}
+)
+
+// policies maps "METHOD /route/pattern" to the roles allowed to call it.
+// Routes under /api without an entry are denied.
+var policies = map[string][]string{
+	// Patients: interns are read-only, only admins delete
+	"GET /api/patients":        staffRoles,
+	"GET /api/patients/search": staffRoles,
+	"GET /api/patients/:id":    staffRoles,
+	"POST /api/patients":       clinicalRoles,
+	"PUT /api/patients/:id":    clinicalRoles,
+	"DELETE /api/patients/:id": adminRoles,
+
+	// Appointments
+	"GET /api/appointments":        staffRoles,
+	"GET /api/appointments/:id":    staffRoles,
+	"POST /api/appointments":       clinicalRoles,
+	"PUT /api/appointments/:id":    clinicalRoles,
+	"DELETE /api/appointments/:id": adminRoles,
+
+	// Health metrics: only doctors record new readings
+	"GET /api/patients/:id/metrics":  staffRoles,
+	"POST /api/patients/:id/metrics": {RoleDoctor},
+
+	// Doctors
+	"GET /api/doctors":     allRoles,
+	"GET /api/doctors/:id": allRoles,
+	"PUT /api/doctors/:id": {RoleSuperAdmin, RoleAdmin, RoleDoctor},
+
+	// Blogs
+	"GET /api/blogs":        allRoles,
+	"GET /api/blogs/:id":    allRoles,
+	"POST /api/blogs":       {RoleSuperAdmin, RoleAdmin, RoleDoctor},
+	"PUT /api/blogs/:id":    {RoleSuperAdmin, RoleAdmin, RoleDoctor},
+	"DELETE /api/blogs/:id": adminRoles,
+
+	// Interns
+	"GET /api/interns":     staffRoles,
+	"GET /api/interns/:id": staffRoles,
+
+	// Hospital
+	"GET /api/hospital": allRoles,
+
+	// Users: anyone may read or edit their own account, the handlers enforce
+	// the self-or-admin rule and keep role changes to admins
+	"GET /api/users":        adminRoles,
+	"GET /api/users/:id":    allRoles,
+	"POST /api/users":       adminRoles,
+	"PUT /api/users/:id":    allRoles,
+	"DELETE /api/users/:id": adminRoles,
+
+	// Settings
+	"GET /api/settings":         allRoles,
+	"PUT /api/settings":         adminRoles,
+	"GET /api/settings/history": adminRoles,
+
+	// Analytics
+	"GET /api/analytics": adminRoles,
+}
+
+// validRoles is the set of roles a user account may hold
+var validRoles = allRoles
+
+func isAdmin(role string) bool {
+	return hasRole(role, adminRoles)
+}
+
+func policyKey(method, path string) string {
+	return method + " " + path
+}
+
+// hasRole reports whether role is one of the allowed roles
+func hasRole(role string, allowed []string) bool {
+	return contains(allowed, role)
+}
+
+// authorizeMiddleware enforces the policy table. It must run after
+// authMiddleware so the caller's role is in the context.
+func authorizeMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString(ctxUserRole)
+		allowed, ok := policies[policyKey(c.Request.Method, c.FullPath())]
+		if !ok || !hasRole(role, allowed) {
+			abortForbidden(c, allowed)
+			return
+		}
+		c.Next()
+	}
+}
+
+// abortForbidden writes the FORBIDDEN error the frontend reacts to
+func abortForbidden(c *gin.Context, requiredRoles []string) {
+	abortWithError(c, http.StatusForbidden, apiError{
+		Code:          codeForbidden,
+		Message:       "You do not have permission to perform this action",
+		RequiredRoles: requiredRoles,
+	})
+}
+
+// checkPolicyCoverage makes sure every registered /api route has a policy
+// entry, so a new handler cannot be added without deciding who may call it.
+func checkPolicyCoverage(routes gin.RoutesInfo) error {
+	var missing []string
+	for _, route := range routes {
+		if !strings.HasPrefix(route.Path, "/api/") {
+			continue
+		}
+		key := policyKey(route.Method, route.Path)
+		if _, ok := policies[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("routes without an authorization policy: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
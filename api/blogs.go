@@ -0,0 +1,133 @@
+package main
+
+import (
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type blogRequest struct {
+	Title      string   `json:"title" binding:"notblank,max=255"`
+	Content    string   `json:"content" binding:"notblank"`
+	Excerpt    string   `json:"excerpt" binding:"max=1000"`
+	CoverImage string   `json:"coverImage" binding:"max=255"`
+	AuthorId   int      `json:"authorId" binding:"gt=0"`
+	AuthorName string   `json:"authorName" binding:"notblank,max=255"`
+	Tags       []string `json:"tags" binding:"max=20,dive,notblank,max=50"`
+}
+
+func (r blogRequest) toBlog() models.Blog {
+	return models.Blog{
+		Title:      strings.TrimSpace(r.Title),
+		Content:    r.Content,
+		Excerpt:    r.Excerpt,
+		CoverImage: r.CoverImage,
+		AuthorId:   r.AuthorId,
+		AuthorName: r.AuthorName,
+		Tags:       r.Tags,
+	}
+}
+
+// respondBlogError maps a failed write, pointing at authorId when it names a
+// user that does not exist
+func respondBlogError(c *gin.Context, err error) {
+	if errors.Is(err, store.ErrInvalidReference) {
+		respondInvalidReference(c, "authorId", "does not match an existing user")
+		return
+	}
+	respondStoreError(c, err, "Blog")
+}
+
+// --- Blog Handlers ---
+func (s *server) getBlogs(c *gin.Context) {
+	q := listQuery{c: c}
+	filter := store.BlogFilter{AuthorID: q.id("authorId")}
+	page := q.page(store.BlogSortKeys)
+	if !q.ok() {
+		return
+	}
+
+	blogs, total, err := s.store.Blogs.List(c.Request.Context(), filter, page)
+	if err != nil {
+		respondStoreError(c, err, "Blog")
+		return
+	}
+
+	respondPage(c, page, total, blogs)
+}
+
+func (s *server) getBlog(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, codeInvalidRequest, "Invalid ID")
+		return
+	}
+
+	blog, err := s.store.Blogs.Get(c.Request.Context(), id)
+	if err != nil {
+		respondStoreError(c, err, "Blog")
+		return
+	}
+
+	c.JSON(http.StatusOK, blog)
+}
+
+func (s *server) createBlog(c *gin.Context) {
+	var blogData blogRequest
+	if !bindJSON(c, &blogData) {
+		return
+	}
+
+	blog := blogData.toBlog()
+	blog.PublishedAt = time.Now().Format(time.RFC3339)
+	if err := s.store.Blogs.Create(c.Request.Context(), &blog); err != nil {
+		respondBlogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, blog)
+}
+
+func (s *server) updateBlog(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, codeInvalidRequest, "Invalid ID")
+		return
+	}
+
+	var blogData blogRequest
+	if !bindJSON(c, &blogData) {
+		return
+	}
+
+	blog := blogData.toBlog()
+	blog.ID = id
+	blog.UpdatedAt = time.Now().Format(time.RFC3339)
+	if err := s.store.Blogs.Update(c.Request.Context(), &blog); err != nil {
+		respondBlogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, blog)
+}
+
+func (s *server) deleteBlog(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, codeInvalidRequest, "Invalid ID")
+		return
+	}
+
+	if err := s.store.Blogs.Delete(c.Request.Context(), id); err != nil {
+		respondStoreError(c, err, "Blog")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Blog deleted"})
+}
@@ -0,0 +1,386 @@
+// Package config loads the service configuration from an optional YAML file
+// and environment variables.
+//
+// Values are resolved in this order, later sources overriding earlier ones:
+// built-in defaults, the YAML file, then CAREHUB_* environment variables.
+// Secrets can also be read from files (for example Docker or Kubernetes
+// secrets) through the *_FILE variables or the passwordFile/jwtSecretFile keys.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv names the environment variable holding the config file path
+// when none is given on the command line.
+const ConfigFileEnv = "CAREHUB_CONFIG"
+
+type Config struct {
+	Server   ServerConfig   `yaml:"server"`
+	Database DatabaseConfig `yaml:"database"`
+	Auth     AuthConfig     `yaml:"auth"`
+	Log      LogConfig      `yaml:"log"`
+	Tracing  TracingConfig  `yaml:"tracing"`
+}
+
+type ServerConfig struct {
+	// Addr is the listen address, for example ":8090" or "127.0.0.1:8090"
+	Addr        string   `yaml:"addr"`
+	CORSOrigins []string `yaml:"corsOrigins"`
+	// ShutdownTimeout is how long in-flight requests and background work get
+	// to finish after SIGTERM or SIGINT before the service exits anyway
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+	// QueryTimeout bounds the database work of one request. Slow routes such
+	// as analytics have longer limits of their own.
+	QueryTimeout time.Duration `yaml:"queryTimeout"`
+}
+
+// Storage drivers accepted in DatabaseConfig.Driver
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMemory   = "memory"
+)
+
+type DatabaseConfig struct {
+	// Driver selects the storage backend. "mysql" and "postgres" use the
+	// connection settings below. "sqlite" stores everything in the file at
+	// Path; "memory" keeps everything in process memory, seeded with demo
+	// data. Both ignore the connection settings.
+	Driver string `yaml:"driver"`
+	// Path is the SQLite database file, created if it does not exist
+	Path string `yaml:"path"`
+	Host string `yaml:"host"`
+	// Port defaults to the standard port of the driver when zero
+	Port         int    `yaml:"port"`
+	User         string `yaml:"user"`
+	Password     string `yaml:"password"`
+	PasswordFile string `yaml:"passwordFile"`
+	Name         string `yaml:"name"`
+	// MigrationsDir replaces the embedded migrations when set
+	MigrationsDir   string        `yaml:"migrationsDir"`
+	MaxOpenConns    int           `yaml:"maxOpenConns"`
+	MaxIdleConns    int           `yaml:"maxIdleConns"`
+	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime"`
+}
+
+// PortOrDefault returns Port, or the standard port of the driver if unset
+func (d DatabaseConfig) PortOrDefault() int {
+	if d.Port != 0 {
+		return d.Port
+	}
+	if d.Driver == DriverPostgres {
+		return 5432
+	}
+	return 3306
+}
+
+type AuthConfig struct {
+	// JWTSecret signs access tokens; a random key is used when empty
+	JWTSecret     string `yaml:"jwtSecret"`
+	JWTSecretFile string `yaml:"jwtSecretFile"`
+}
+
+type LogConfig struct {
+	// Level is the minimum level written: debug, info, warn or error
+	Level string `yaml:"level"`
+}
+
+// Trace exporters accepted in TracingConfig.Exporter
+const (
+	TracingNone   = "none"
+	TracingOTLP   = "otlp"
+	TracingStdout = "stdout"
+)
+
+type TracingConfig struct {
+	// Exporter is "none", "otlp" (OTLP over HTTP) or "stdout"
+	Exporter string `yaml:"exporter"`
+	// Endpoint is the OTLP collector URL, for example
+	// http://localhost:4318. When empty the standard OTEL_EXPORTER_OTLP_*
+	// variables apply.
+	Endpoint string `yaml:"endpoint"`
+	// File receives the spans of the stdout exporter instead of stdout
+	File        string `yaml:"file"`
+	ServiceName string `yaml:"serviceName"`
+	// SampleRatio is the share of new traces recorded, from 0 to 1.
+	// Requests that arrive with a sampled parent are always recorded.
+	SampleRatio float64 `yaml:"sampleRatio"`
+}
+
+// Default returns the configuration used when nothing else is specified
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Addr:            ":8090",
+			CORSOrigins:     []string{"http://localhost:8080", "http://192.168.1.7:8080"},
+			ShutdownTimeout: 15 * time.Second,
+			QueryTimeout:    5 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Driver:          DriverMySQL,
+			Path:            "carehub.db",
+			Host:            "localhost",
+			User:            "root",
+			Name:            "carehub",
+			MaxOpenConns:    25,
+			MaxIdleConns:    25,
+			ConnMaxLifetime: 5 * time.Minute,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		Tracing: TracingConfig{
+			Exporter:    TracingNone,
+			ServiceName: "carehub-api",
+			SampleRatio: 1,
+		},
+	}
+}
+
+// Load builds the configuration from defaults, the YAML file at path (if not
+// empty) and the environment, then validates it.
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	if path == "" {
+		path = os.Getenv(ConfigFileEnv)
+	}
+	if path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return cfg, fmt.Errorf("failed to read config file: %v", err)
+		}
+		decoder := yaml.NewDecoder(f)
+		decoder.KnownFields(true)
+		err = decoder.Decode(&cfg)
+		f.Close()
+		if err != nil && err != io.EOF {
+			return cfg, fmt.Errorf("failed to parse config file %s: %v", path, err)
+		}
+	}
+
+	if err := applyEnv(&cfg); err != nil {
+		return cfg, err
+	}
+	if err := resolveSecrets(&cfg); err != nil {
+		return cfg, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+func applyEnv(cfg *Config) error {
+	setString := func(name string, dest *string) {
+		if v, ok := os.LookupEnv(name); ok {
+			*dest = v
+		}
+	}
+	setInt := func(name string, dest *int) error {
+		if v, ok := os.LookupEnv(name); ok {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("%s must be an integer", name)
+			}
+			*dest = n
+		}
+		return nil
+	}
+	setDuration := func(name string, dest *time.Duration) error {
+		if v, ok := os.LookupEnv(name); ok {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("%s must be a duration such as 5m", name)
+			}
+			*dest = d
+		}
+		return nil
+	}
+
+	setString("CAREHUB_HTTP_ADDR", &cfg.Server.Addr)
+	if v, ok := os.LookupEnv("CAREHUB_CORS_ORIGINS"); ok {
+		cfg.Server.CORSOrigins = splitList(v)
+	}
+	if err := setDuration("CAREHUB_SHUTDOWN_TIMEOUT", &cfg.Server.ShutdownTimeout); err != nil {
+		return err
+	}
+	if err := setDuration("CAREHUB_QUERY_TIMEOUT", &cfg.Server.QueryTimeout); err != nil {
+		return err
+	}
+
+	setString("CAREHUB_DB_DRIVER", &cfg.Database.Driver)
+	setString("CAREHUB_DB_PATH", &cfg.Database.Path)
+	setString("CAREHUB_DB_HOST", &cfg.Database.Host)
+	setString("CAREHUB_DB_USER", &cfg.Database.User)
+	setString("CAREHUB_DB_PASSWORD", &cfg.Database.Password)
+	setString("CAREHUB_DB_PASSWORD_FILE", &cfg.Database.PasswordFile)
+	setString("CAREHUB_DB_NAME", &cfg.Database.Name)
+	setString("CAREHUB_MIGRATIONS_DIR", &cfg.Database.MigrationsDir)
+	if err := setInt("CAREHUB_DB_PORT", &cfg.Database.Port); err != nil {
+		return err
+	}
+	if err := setInt("CAREHUB_DB_MAX_OPEN_CONNS", &cfg.Database.MaxOpenConns); err != nil {
+		return err
+	}
+	if err := setInt("CAREHUB_DB_MAX_IDLE_CONNS", &cfg.Database.MaxIdleConns); err != nil {
+		return err
+	}
+	if err := setDuration("CAREHUB_DB_CONN_MAX_LIFETIME", &cfg.Database.ConnMaxLifetime); err != nil {
+		return err
+	}
+
+	// JWT_SECRET predates the config package and is still honoured
+	setString("JWT_SECRET", &cfg.Auth.JWTSecret)
+	setString("CAREHUB_JWT_SECRET", &cfg.Auth.JWTSecret)
+	setString("CAREHUB_JWT_SECRET_FILE", &cfg.Auth.JWTSecretFile)
+
+	setString("CAREHUB_LOG_LEVEL", &cfg.Log.Level)
+
+	setString("CAREHUB_TRACING_EXPORTER", &cfg.Tracing.Exporter)
+	setString("CAREHUB_TRACING_ENDPOINT", &cfg.Tracing.Endpoint)
+	setString("CAREHUB_TRACING_FILE", &cfg.Tracing.File)
+	setString("CAREHUB_TRACING_SERVICE_NAME", &cfg.Tracing.ServiceName)
+	if v, ok := os.LookupEnv("CAREHUB_TRACING_SAMPLE_RATIO"); ok {
+		ratio, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("CAREHUB_TRACING_SAMPLE_RATIO must be a number between 0 and 1")
+		}
+		cfg.Tracing.SampleRatio = ratio
+	}
+
+	return nil
+}
+
+// resolveSecrets replaces secrets with the contents of their *File settings
+func resolveSecrets(cfg *Config) error {
+	secrets := []struct {
+		file string
+		dest *string
+	}{
+		{cfg.Database.PasswordFile, &cfg.Database.Password},
+		{cfg.Auth.JWTSecretFile, &cfg.Auth.JWTSecret},
+	}
+
+	for _, s := range secrets {
+		if s.file == "" {
+			continue
+		}
+		data, err := os.ReadFile(s.file)
+		if err != nil {
+			return fmt.Errorf("failed to read secret file: %v", err)
+		}
+		*s.dest = strings.TrimRight(string(data), "\r\n")
+	}
+	return nil
+}
+
+// Validate reports every invalid setting at once
+func (c Config) Validate() error {
+	var errs []string
+
+	if c.Server.Addr == "" {
+		errs = append(errs, "server.addr is required")
+	} else if i := strings.LastIndexByte(c.Server.Addr, ':'); i < 0 {
+		errs = append(errs, "server.addr must include a port, for example :8090")
+	} else if port, err := strconv.Atoi(c.Server.Addr[i+1:]); err != nil || port < 0 || port > 65535 {
+		errs = append(errs, "server.addr has an invalid port")
+	}
+	for _, origin := range c.Server.CORSOrigins {
+		if u, err := url.Parse(origin); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Sprintf("server.corsOrigins: %q is not a valid origin", origin))
+		}
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		errs = append(errs, "server.shutdownTimeout must be positive")
+	}
+	if c.Server.QueryTimeout <= 0 {
+		errs = append(errs, "server.queryTimeout must be positive")
+	}
+
+	switch c.Database.Driver {
+	case DriverMySQL, DriverPostgres:
+		if c.Database.Host == "" {
+			errs = append(errs, "database.host is required")
+		}
+		if c.Database.Port < 0 || c.Database.Port > 65535 {
+			errs = append(errs, "database.port must be between 1 and 65535")
+		}
+		if c.Database.User == "" {
+			errs = append(errs, "database.user is required")
+		}
+		if c.Database.Name == "" {
+			errs = append(errs, "database.name is required")
+		}
+	case DriverSQLite:
+		if c.Database.Path == "" {
+			errs = append(errs, "database.path is required")
+		}
+	case DriverMemory:
+	default:
+		errs = append(errs, fmt.Sprintf("database.driver must be %q, %q, %q or %q",
+			DriverMySQL, DriverPostgres, DriverSQLite, DriverMemory))
+	}
+	if c.Database.MaxOpenConns < 0 || c.Database.MaxIdleConns < 0 {
+		errs = append(errs, "database connection limits must not be negative")
+	}
+	if c.Database.MigrationsDir != "" {
+		if info, err := os.Stat(c.Database.MigrationsDir); err != nil || !info.IsDir() {
+			errs = append(errs, "database.migrationsDir must be an existing directory")
+		}
+	}
+
+	if c.Auth.JWTSecret != "" && len(c.Auth.JWTSecret) < 32 {
+		errs = append(errs, "auth.jwtSecret must be at least 32 characters")
+	}
+
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, "log.level must be debug, info, warn or error")
+	}
+
+	switch c.Tracing.Exporter {
+	case TracingNone, TracingStdout:
+	case TracingOTLP:
+		if c.Tracing.Endpoint != "" {
+			if u, err := url.Parse(c.Tracing.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs = append(errs, "tracing.endpoint must be an http or https URL")
+			}
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("tracing.exporter must be %q, %q or %q", TracingNone, TracingOTLP, TracingStdout))
+	}
+	if c.Tracing.ServiceName == "" {
+		errs = append(errs, "tracing.serviceName is required")
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, "tracing.sampleRatio must be between 0 and 1")
+	}
+
+	if len(errs) > 0 {
+		return errors.New("invalid configuration: " + strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -1,30 +1,48 @@
-
 package db
 
 import (
+	"carehub-microservice/config"
 	"database/sql"
 	"fmt"
-	_ "github.com/go-sql-driver/mysql"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/go-sql-driver/mysql"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "modernc.org/sqlite"
 )
 
 var DB *sql.DB
 
-func InitDB() error {
-	// Replace these with your actual MySQL credentials
-	username := "root"
-	password := ""
-	host := "localhost"
-	port := "3306"
-	dbname := "carehub"
+// migrationsDir overrides the embedded migrations when not empty
+var migrationsDir string
 
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", 
-		username, password, host, port, dbname)
+// Open connects to the database without running migrations
+func Open(cfg config.DatabaseConfig) error {
+	var driverName, dsn string
+	switch Dialect(cfg.Driver) {
+	case MySQL:
+		driverName, dsn = "mysql", mysqlDSN(cfg)
+	case SQLite:
+		driverName, dsn = "sqlite", sqliteDSN(cfg)
+	case Postgres:
+		driverName, dsn = "pgx", postgresDSN(cfg)
+	default:
+		return fmt.Errorf("unsupported database driver %q", cfg.Driver)
+	}
 
 	var err error
-	DB, err = sql.Open("mysql", dsn)
+	DB, err = sql.Open(driverName, dsn)
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %v", err)
 	}
+	DBDialect = Dialect(cfg.Driver)
+
+	DB.SetMaxOpenConns(cfg.MaxOpenConns)
+	DB.SetMaxIdleConns(cfg.MaxIdleConns)
+	DB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	migrationsDir = cfg.MigrationsDir
 
 	// Test the connection
 	err = DB.Ping()
@@ -32,13 +50,45 @@ func InitDB() error {
 		return fmt.Errorf("failed to ping database: %v", err)
 	}
 
-	// Run migrations
-	err = RunMigrations(DB)
-	if err != nil {
-		return fmt.Errorf("failed to run migrations: %v", err)
+	return nil
+}
+
+// mysqlDSN asks for found rather than changed rows in UPDATE results, so an
+// update that leaves a row as it was is not mistaken for a missing row.
+func mysqlDSN(cfg config.DatabaseConfig) string {
+	dsn := mysql.NewConfig()
+	dsn.User = cfg.User
+	dsn.Passwd = cfg.Password
+	dsn.Net = "tcp"
+	dsn.Addr = net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.PortOrDefault()))
+	dsn.DBName = cfg.Name
+	dsn.ParseTime = true
+	dsn.ClientFoundRows = true
+	return dsn.FormatDSN()
+}
+
+// postgresDSN pins the session time zone to UTC so TIMESTAMP columns hold
+// the same values as on MySQL, where the driver converts to UTC.
+func postgresDSN(cfg config.DatabaseConfig) string {
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.User, cfg.Password),
+		Host:     net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.PortOrDefault())),
+		Path:     "/" + cfg.Name,
+		RawQuery: url.Values{"timezone": {"UTC"}}.Encode(),
 	}
+	return dsn.String()
+}
 
-	return nil
+// sqliteDSN enables foreign keys, which SQLite leaves off by default, and
+// stores times in a format its date functions understand.
+func sqliteDSN(cfg config.DatabaseConfig) string {
+	params := url.Values{}
+	params.Set("_time_format", "sqlite")
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	return "file:" + cfg.Path + "?" + params.Encode()
 }
 
 func CloseDB() {
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dialect identifies the SQL flavour of a database. Its value matches the
+// database.driver setting and the name of the migrations directory.
+type Dialect string
+
+const (
+	MySQL    Dialect = "mysql"
+	SQLite   Dialect = "sqlite"
+	Postgres Dialect = "postgres"
+)
+
+// DBDialect is the dialect of DB
+var DBDialect Dialect
+
+// migrations returns the embedded migration set for d
+func (d Dialect) migrations(fsys fs.FS) (fs.FS, error) {
+	return fs.Sub(fsys, string(d))
+}
+
+// Rebind rewrites the ? placeholders in query to the form d expects. Queries
+// are written with ?, which MySQL and SQLite take as is; Postgres numbers its
+// parameters $1, $2 and so on. Question marks inside quoted strings and
+// identifiers are left alone.
+func (d Dialect) Rebind(query string) string {
+	if d != Postgres || !strings.Contains(query, "?") {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+	n := 0
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '?':
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func (d Dialect) tableExists(ctx context.Context, q queryer, table string) (bool, error) {
+	var exists bool
+	var err error
+	switch d {
+	case SQLite:
+		err = q.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM sqlite_master
+			WHERE type = 'table' AND name = ?)`, table).Scan(&exists)
+	case Postgres:
+		err = q.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM information_schema.tables
+			WHERE table_schema = current_schema() AND table_name = $1)`, table).Scan(&exists)
+	default:
+		err = q.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM information_schema.tables
+			WHERE table_schema = DATABASE() AND table_name = ?)`, table).Scan(&exists)
+	}
+	return exists, err
+}
+
+// lock keeps two instances from migrating the same database at once and
+// returns the function that releases it. SQLite has no named locks, but its
+// DDL is transactional: a second migrator fails on the schema_migrations
+// primary key and rolls the whole migration back instead of applying it twice.
+func (d Dialect) lock(ctx context.Context, conn *sql.Conn) (func(), error) {
+	switch d {
+	case SQLite:
+		return func() {}, nil
+	case Postgres:
+		// pg_advisory_lock waits indefinitely, so bound the wait ourselves
+		waitCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+		defer cancel()
+		if _, err := conn.ExecContext(waitCtx, "SELECT pg_advisory_lock(hashtext($1))", migrationLockName); err != nil {
+			if waitCtx.Err() == context.DeadlineExceeded {
+				return nil, fmt.Errorf("timed out waiting for migration lock")
+			}
+			return nil, fmt.Errorf("failed to acquire migration lock: %v", err)
+		}
+		return func() {
+			conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock(hashtext($1))", migrationLockName)
+		}, nil
+	}
+
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 60)", migrationLockName).Scan(&locked); err != nil {
+		return nil, fmt.Errorf("failed to acquire migration lock: %v", err)
+	}
+	if locked.Int64 != 1 {
+		return nil, fmt.Errorf("timed out waiting for migration lock")
+	}
+	// Release the lock even if ctx has been cancelled in the meantime
+	return func() {
+		conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", migrationLockName)
+	}, nil
+}
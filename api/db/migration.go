@@ -1,50 +1,488 @@
-
 package db
 
 import (
+	embedded "carehub-microservice/migrations"
+	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"fmt"
-	"io/ioutil"
-	"log"
-	"path/filepath"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slog"
 )
 
-func RunMigrations(db *sql.DB) error {
-	migrations, err := ioutil.ReadDir("migrations")
+// migrationLockName is the MySQL named lock that keeps two instances from
+// migrating the same database at once.
+const migrationLockName = "carehub_schema_migrations"
+
+// legacyBaselineVersion is the last migration that ran on every boot before
+// migrations were tracked. Databases created back then already contain its
+// schema and seed rows, so it is recorded as applied instead of re-run.
+const legacyBaselineVersion = 2
+
+// Migration is a versioned pair of NNN_name.up.sql and NNN_name.down.sql files.
+// A plain NNN_name.sql file is treated as an up migration without a down.
+type Migration struct {
+	Version  int64
+	Name     string
+	Checksum string
+	Up       string
+	Down     string
+	HasDown  bool
+}
+
+// AppliedMigration is a row of the schema_migrations table
+type AppliedMigration struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// MigrationStatus describes one known migration and whether it is applied
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+	HasDown   bool
+}
+
+// States of MigrationProgress
+const (
+	MigrationsPending  = "pending"
+	MigrationsRunning  = "running"
+	MigrationsComplete = "complete"
+	MigrationsFailed   = "failed"
+)
+
+// MigrationProgress describes what RunMigrations has done in this process
+type MigrationProgress struct {
+	State string
+	// Version is the latest applied migration once State is MigrationsComplete
+	Version int64
+	Err     error
+}
+
+var progress = struct {
+	sync.Mutex
+	MigrationProgress
+}{MigrationProgress: MigrationProgress{State: MigrationsPending}}
+
+func setProgress(p MigrationProgress) {
+	progress.Lock()
+	progress.MigrationProgress = p
+	progress.Unlock()
+}
+
+// CurrentMigrationProgress reports the state of RunMigrations, which is
+// MigrationsPending until it is first called.
+func CurrentMigrationProgress() MigrationProgress {
+	progress.Lock()
+	defer progress.Unlock()
+	return progress.MigrationProgress
+}
+
+// parseMigrationName splits names like "001_create_tables.up.sql" into
+// version 1, base name "001_create_tables" and direction "up".
+func parseMigrationName(file string) (version int64, name string, direction string, err error) {
+	name = strings.TrimSuffix(file, ".sql")
+	direction = "up"
+	if strings.HasSuffix(name, ".up") {
+		name = strings.TrimSuffix(name, ".up")
+	} else if strings.HasSuffix(name, ".down") {
+		name = strings.TrimSuffix(name, ".down")
+		direction = "down"
+	}
+
+	prefix := name
+	if i := strings.IndexByte(name, '_'); i >= 0 {
+		prefix = name[:i]
+	}
+	version, err = strconv.ParseInt(prefix, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, "", "", fmt.Errorf("migration %s must start with a positive version number", file)
+	}
+	return version, name, direction, nil
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// migrationSource returns the override directory if one is configured, which
+// lets developers edit migrations without rebuilding, and the embedded
+// migrations for the current dialect otherwise, along with a description for
+// logging.
+func migrationSource() (fs.FS, string, error) {
+	if migrationsDir != "" {
+		return os.DirFS(migrationsDir), migrationsDir, nil
+	}
+	fsys, err := DBDialect.migrations(embedded.FS)
+	return fsys, "embedded " + string(DBDialect) + " migrations", err
+}
+
+// LoadMigrations reads every .sql file at the root of fsys, ordered by version
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.ReadDir(fsys, ".")
 	if err != nil {
-		return fmt.Errorf("failed to read migrations directory: %v", err)
+		return nil, fmt.Errorf("failed to read migrations directory: %v", err)
 	}
 
-	for _, migration := range migrations {
-		if !strings.HasSuffix(migration.Name(), ".sql") {
+	byVersion := map[int64]*Migration{}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".sql") {
 			continue
 		}
 
-		content, err := ioutil.ReadFile(filepath.Join("migrations", migration.Name()))
+		version, name, direction, err := parseMigrationName(file.Name())
 		if err != nil {
-			return fmt.Errorf("failed to read migration file %s: %v", migration.Name(), err)
+			return nil, err
 		}
 
-		log.Printf("Running migration: %s\n", migration.Name())
-		
-		// Split the content into individual statements
-		statements := strings.Split(string(content), ";")
-		
-		for _, stmt := range statements {
-			stmt = strings.TrimSpace(stmt)
-			if stmt == "" {
-				continue
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", m.Name, name, version)
+		}
+
+		content, err := fs.ReadFile(fsys, file.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration file %s: %v", file.Name(), err)
+		}
+
+		// Reject unparseable files before anything is executed
+		if _, err := DBDialect.splitStatements(string(content)); err != nil {
+			return nil, fmt.Errorf("failed to parse migration file %s: %v", file.Name(), err)
+		}
+
+		if direction == "down" {
+			m.Down = string(content)
+			m.HasDown = true
+			continue
+		}
+		if m.Checksum != "" {
+			return nil, fmt.Errorf("migration %s has more than one up file", name)
+		}
+		m.Up = string(content)
+		m.Checksum = checksum(content)
+	}
+
+	var migrations []Migration
+	for _, m := range byVersion {
+		if m.Checksum == "" {
+			return nil, fmt.Errorf("migration %s has a down file but no up file", m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func ensureMigrationsTable(ctx context.Context, q queryer) error {
+	_, err := q.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		checksum CHAR(64) NOT NULL,
+		applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`)
+	return err
+}
+
+// AppliedMigrations returns the rows of schema_migrations keyed by version
+func AppliedMigrations(ctx context.Context, q queryer) (map[int64]AppliedMigration, error) {
+	rows, err := q.QueryContext(ctx, "SELECT version, name, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int64]AppliedMigration{}
+	for rows.Next() {
+		var m AppliedMigration
+		if err := rows.Scan(&m.Version, &m.Name, &m.Checksum, &m.AppliedAt); err != nil {
+			return nil, err
+		}
+		applied[m.Version] = m
+	}
+	return applied, rows.Err()
+}
+
+func recordMigration(ctx context.Context, q queryer, m Migration) error {
+	_, err := q.ExecContext(ctx, DBDialect.Rebind("INSERT INTO schema_migrations (version, name, checksum) VALUES (?, ?, ?)"),
+		m.Version, m.Name, m.Checksum)
+	return err
+}
+
+// verifyApplied refuses to continue if an applied migration was edited or removed
+func verifyApplied(migrations []Migration, applied map[int64]AppliedMigration) error {
+	byVersion := map[int64]Migration{}
+	for _, m := range migrations {
+		byVersion[m.Version] = m
+	}
+
+	for version, a := range applied {
+		m, ok := byVersion[version]
+		if !ok {
+			return fmt.Errorf("applied migration %s (version %d) is missing", a.Name, version)
+		}
+		if m.Checksum != a.Checksum {
+			return fmt.Errorf("migration %s was modified after it was applied (checksum %s, expected %s)",
+				m.Name, m.Checksum, a.Checksum)
+		}
+	}
+	return nil
+}
+
+// baselineLegacy records the pre-tracking migrations as applied on databases
+// that were created before schema_migrations existed.
+func baselineLegacy(ctx context.Context, q queryer, migrations []Migration) error {
+	for _, m := range migrations {
+		if m.Version > legacyBaselineVersion {
+			break
+		}
+		if err := recordMigration(ctx, q, m); err != nil {
+			return fmt.Errorf("failed to baseline migration %s: %v", m.Name, err)
+		}
+		slog.Info("Recorded existing migration as applied", "migration", m.Name)
+	}
+	return nil
+}
+
+func execMigration(ctx context.Context, conn *sql.Conn, content string, record func(tx *sql.Tx) error) error {
+	statements, err := DBDialect.splitStatements(content)
+	if err != nil {
+		return err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range statements {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	if err := record(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func applyMigration(ctx context.Context, conn *sql.Conn, m Migration) error {
+	slog.Info("Running migration", "migration", m.Name)
+	err := execMigration(ctx, conn, m.Up, func(tx *sql.Tx) error {
+		return recordMigration(ctx, tx, m)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to execute migration %s: %v", m.Name, err)
+	}
+	slog.Info("Completed migration", "migration", m.Name)
+	return nil
+}
+
+func revertMigration(ctx context.Context, conn *sql.Conn, m Migration) error {
+	if !m.HasDown {
+		return fmt.Errorf("migration %s has no down migration", m.Name)
+	}
+
+	slog.Info("Reverting migration", "migration", m.Name)
+	err := execMigration(ctx, conn, m.Down, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, DBDialect.Rebind("DELETE FROM schema_migrations WHERE version = ?"), m.Version)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revert migration %s: %v", m.Name, err)
+	}
+	slog.Info("Reverted migration", "migration", m.Name)
+	return nil
+}
+
+// withMigrations loads the migration files, takes the migration lock and
+// verifies the applied versions before calling fn. Each migration runs in
+// its own transaction; note that MySQL commits DDL statements implicitly,
+// so only the data changes of a failed migration are rolled back there.
+func withMigrations(ctx context.Context, db *sql.DB, fn func(ctx context.Context, conn *sql.Conn, migrations []Migration, applied map[int64]AppliedMigration) error) error {
+	fsys, source, err := migrationSource()
+	if err != nil {
+		return err
+	}
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return fmt.Errorf("%s: %v", source, err)
+	}
+	slog.Info("Loaded migrations", "count", len(migrations), "source", source)
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	unlock, err := DBDialect.lock(ctx, conn)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	tracked, err := DBDialect.tableExists(ctx, conn, "schema_migrations")
+	if err != nil {
+		return err
+	}
+	if !tracked {
+		legacy, err := DBDialect.tableExists(ctx, conn, "users")
+		if err != nil {
+			return err
+		}
+		if err := ensureMigrationsTable(ctx, conn); err != nil {
+			return fmt.Errorf("failed to create schema_migrations table: %v", err)
+		}
+		if legacy {
+			if err := baselineLegacy(ctx, conn, migrations); err != nil {
+				return err
 			}
+		}
+	}
+
+	applied, err := AppliedMigrations(ctx, conn)
+	if err != nil {
+		return fmt.Errorf("failed to read applied migrations: %v", err)
+	}
+	if err := verifyApplied(migrations, applied); err != nil {
+		return err
+	}
+
+	return fn(ctx, conn, migrations, applied)
+}
 
-			_, err = db.Exec(stmt)
-			if err != nil {
-				return fmt.Errorf("failed to execute migration %s: %v", migration.Name(), err)
+// RunMigrations applies every pending migration in version order and
+// records its progress for CurrentMigrationProgress. Cancelling ctx stops it
+// before the next migration; one that has already started is finished.
+func RunMigrations(ctx context.Context, db *sql.DB) error {
+	setProgress(MigrationProgress{State: MigrationsRunning})
+
+	var latest int64
+	err := withMigrations(ctx, db, func(ctx context.Context, conn *sql.Conn, migrations []Migration, applied map[int64]AppliedMigration) error {
+		if len(migrations) > 0 {
+			latest = migrations[len(migrations)-1].Version
+		}
+		return migrateTo(ctx, conn, migrations, applied, -1)
+	})
+	if err != nil {
+		setProgress(MigrationProgress{State: MigrationsFailed, Err: err})
+		return err
+	}
+	setProgress(MigrationProgress{State: MigrationsComplete, Version: latest})
+	return nil
+}
+
+// MigrateTo applies or reverts migrations until version is the latest applied
+// one. A negative version means the newest available, 0 reverts everything.
+func MigrateTo(db *sql.DB, version int64) error {
+	return withMigrations(context.Background(), db, func(ctx context.Context, conn *sql.Conn, migrations []Migration, applied map[int64]AppliedMigration) error {
+		return migrateTo(ctx, conn, migrations, applied, version)
+	})
+}
+
+// migrateTo implements MigrateTo. ctx is checked between migrations, but a
+// migration that has started always runs to the end: MySQL commits DDL
+// implicitly, so interrupting one could leave the schema half applied.
+func migrateTo(ctx context.Context, conn *sql.Conn, migrations []Migration, applied map[int64]AppliedMigration, version int64) error {
+	if version > 0 {
+		found := false
+		for _, m := range migrations {
+			found = found || m.Version == version
+		}
+		if !found {
+			return fmt.Errorf("unknown migration version %d", version)
+		}
+	}
+
+	// Revert newer migrations, newest first
+	for i := len(migrations) - 1; i >= 0 && version >= 0; i-- {
+		m := migrations[i]
+		if _, ok := applied[m.Version]; ok && m.Version > version {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := revertMigration(context.Background(), conn, m); err != nil {
+				return err
 			}
 		}
-		
-		log.Printf("Successfully completed migration: %s\n", migration.Name())
 	}
 
+	// Apply pending migrations up to the target, oldest first
+	for _, m := range migrations {
+		if version >= 0 && m.Version > version {
+			break
+		}
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := applyMigration(context.Background(), conn, m); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// MigrateDown reverts the most recently applied steps migrations
+func MigrateDown(db *sql.DB, steps int) error {
+	return withMigrations(context.Background(), db, func(ctx context.Context, conn *sql.Conn, migrations []Migration, applied map[int64]AppliedMigration) error {
+		for i := len(migrations) - 1; i >= 0 && steps > 0; i-- {
+			m := migrations[i]
+			if _, ok := applied[m.Version]; !ok {
+				continue
+			}
+			if err := revertMigration(ctx, conn, m); err != nil {
+				return err
+			}
+			steps--
+		}
+		return nil
+	})
+}
+
+// Status lists every known migration and whether it has been applied
+func Status(db *sql.DB) ([]MigrationStatus, error) {
+	var status []MigrationStatus
+	err := withMigrations(context.Background(), db, func(ctx context.Context, conn *sql.Conn, migrations []Migration, applied map[int64]AppliedMigration) error {
+		for _, m := range migrations {
+			a, ok := applied[m.Version]
+			status = append(status, MigrationStatus{
+				Version:   m.Version,
+				Name:      m.Name,
+				Applied:   ok,
+				AppliedAt: a.AppliedAt,
+				HasDown:   m.HasDown,
+			})
+		}
+		return nil
+	})
+	return status, err
+}
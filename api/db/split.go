@@ -0,0 +1,234 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+)
+
+const defaultDelimiter = ";"
+
+// splitStatements splits a migration script for d into individual
+// statements.
+//
+// It understands single, double and backtick quoted strings with
+// doubled-quote escapes, "--" line comments and /* */ block comments, so
+// delimiters inside any of them do not end a statement. Backslash escapes
+// follow the dialect: MySQL applies them in every string, Postgres only in
+// E'...' strings and SQLite never.
+//
+// Postgres function and trigger bodies are written as dollar-quoted strings,
+// which are kept whole:
+//
+//	CREATE FUNCTION touch() RETURNS trigger AS $$
+//	BEGIN NEW.updated_at = NOW(); RETURN NEW; END;
+//	$$ LANGUAGE plpgsql;
+//
+// Like the mysql client it also accepts "DELIMITER <token>" lines, which
+// makes it possible to write MySQL stored procedures and triggers whose
+// bodies contain semicolons:
+//
+//	DELIMITER $$
+//	CREATE TRIGGER ... BEGIN ...; ...; END$$
+//	DELIMITER ;
+//
+// Comments are dropped from the returned statements, except MySQL
+// executable comments (/*! ... */) and optimizer hints (/*+ ... */).
+func (d Dialect) splitStatements(content string) ([]string, error) {
+	var statements []string
+	var current strings.Builder
+	delimiter := defaultDelimiter
+	lineStart := true
+
+	flush := func() {
+		if stmt := strings.TrimSpace(current.String()); stmt != "" {
+			statements = append(statements, stmt)
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(content); {
+		ch := content[i]
+
+		// DELIMITER is a client command and only valid at the start of a line
+		if lineStart {
+			j := i
+			for j < len(content) && (content[j] == ' ' || content[j] == '\t') {
+				j++
+			}
+			if newDelimiter, end, ok := parseDelimiterCommand(content, j); ok {
+				if strings.TrimSpace(current.String()) != "" {
+					return nil, fmt.Errorf("line %d: DELIMITER must not appear inside a statement", lineNumber(content, j))
+				}
+				if newDelimiter == "" {
+					return nil, fmt.Errorf("line %d: DELIMITER requires a delimiter", lineNumber(content, j))
+				}
+				delimiter = newDelimiter
+				i = end
+				continue
+			}
+		}
+		lineStart = false
+
+		switch {
+		case strings.HasPrefix(content[i:], delimiter):
+			flush()
+			i += len(delimiter)
+
+		case ch == '\'' || ch == '"' || ch == '`':
+			end, err := scanQuoted(content, i, d.backslashEscapes(content, i))
+			if err != nil {
+				return nil, err
+			}
+			current.WriteString(content[i:end])
+			i = end
+
+		case d == Postgres && dollarQuoteTag(content, i) != "":
+			tag := dollarQuoteTag(content, i)
+			end := strings.Index(content[i+len(tag):], tag)
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated %s quoted string", lineNumber(content, i), tag)
+			}
+			end += i + 2*len(tag)
+			current.WriteString(content[i:end])
+			i = end
+
+		case isLineComment(content, i):
+			end := strings.IndexByte(content[i:], '\n')
+			if end < 0 {
+				i = len(content)
+			} else {
+				i += end
+			}
+
+		case strings.HasPrefix(content[i:], "/*"):
+			end := strings.Index(content[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated block comment", lineNumber(content, i))
+			}
+			end += i + 4
+			if strings.HasPrefix(content[i:], "/*!") || strings.HasPrefix(content[i:], "/*+") {
+				current.WriteString(content[i:end])
+			} else {
+				current.WriteByte(' ')
+			}
+			i = end
+
+		default:
+			current.WriteByte(ch)
+			if ch == '\n' {
+				lineStart = true
+			}
+			i++
+		}
+	}
+
+	flush()
+	return statements, nil
+}
+
+// parseDelimiterCommand recognizes "DELIMITER <token>" at position i and
+// returns the new delimiter and the index just past the end of the line.
+func parseDelimiterCommand(content string, i int) (string, int, bool) {
+	const keyword = "DELIMITER"
+	if len(content)-i < len(keyword) || !strings.EqualFold(content[i:i+len(keyword)], keyword) {
+		return "", 0, false
+	}
+	rest := content[i+len(keyword):]
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' && rest[0] != '\n' && rest[0] != '\r' {
+		return "", 0, false
+	}
+
+	end := strings.IndexByte(rest, '\n')
+	if end < 0 {
+		end = len(rest)
+	}
+	fields := strings.Fields(rest[:end])
+	delimiter := ""
+	if len(fields) > 0 {
+		delimiter = fields[0]
+	}
+	return delimiter, i + len(keyword) + end, true
+}
+
+// backslashEscapes reports whether a backslash escapes the next character
+// in the string whose opening quote is at i. Identifiers never take them.
+func (d Dialect) backslashEscapes(content string, i int) bool {
+	if content[i] != '\'' && (content[i] != '"' || d != MySQL) {
+		return false
+	}
+	switch d {
+	case MySQL:
+		return true
+	case Postgres:
+		// E'...' escape strings; plain strings follow standard_conforming_strings
+		return i > 0 && (content[i-1] == 'E' || content[i-1] == 'e') && (i == 1 || !isIdentChar(content[i-2]))
+	}
+	return false
+}
+
+// dollarQuoteTag returns the $tag$ or $$ opening a Postgres dollar-quoted
+// string at i, or "" if there is none. Positional parameters such as $1 are
+// not tags, since tags cannot start with a digit.
+func dollarQuoteTag(content string, i int) string {
+	if content[i] != '$' || (i > 0 && isIdentChar(content[i-1])) {
+		return ""
+	}
+	for j := i + 1; j < len(content); j++ {
+		switch c := content[j]; {
+		case c == '$':
+			return content[i : j+1]
+		case c >= '0' && c <= '9':
+			if j == i+1 {
+				return ""
+			}
+		case !isIdentChar(c):
+			return ""
+		}
+	}
+	return ""
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+// scanQuoted returns the index just past the quoted string starting at i
+func scanQuoted(content string, i int, backslashEscapes bool) (int, error) {
+	quote := content[i]
+	for j := i + 1; j < len(content); j++ {
+		switch content[j] {
+		case '\\':
+			if backslashEscapes {
+				j++
+			}
+		case quote:
+			// A doubled quote is an escaped quote character
+			if j+1 < len(content) && content[j+1] == quote {
+				j++
+				continue
+			}
+			return j + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("line %d: unterminated %c quoted string", lineNumber(content, i), quote)
+}
+
+// isLineComment reports whether a "--" comment starts at i. MySQL requires
+// the dashes to be followed by whitespace or the end of the input.
+func isLineComment(content string, i int) bool {
+	if !strings.HasPrefix(content[i:], "--") {
+		return false
+	}
+	if i+2 == len(content) {
+		return true
+	}
+	switch content[i+2] {
+	case ' ', '\t', '\n', '\r':
+		return true
+	}
+	return false
+}
+
+func lineNumber(content string, i int) int {
+	return strings.Count(content[:i], "\n") + 1
+}
@@ -0,0 +1,212 @@
+package db
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		content string
+		want    []string
+	}{
+		{
+			name:    "statements",
+			content: "CREATE TABLE a (id INT);\nCREATE TABLE b (id INT);\n",
+			want:    []string{"CREATE TABLE a (id INT)", "CREATE TABLE b (id INT)"},
+		},
+		{
+			name:    "missing final delimiter",
+			content: "DELETE FROM a;\nDELETE FROM b",
+			want:    []string{"DELETE FROM a", "DELETE FROM b"},
+		},
+		{
+			name:    "empty statements",
+			content: ";;\n  ;\nDELETE FROM a;;",
+			want:    []string{"DELETE FROM a"},
+		},
+		{
+			name:    "delimiter in single quotes",
+			content: "INSERT INTO a VALUES ('x;y');",
+			want:    []string{"INSERT INTO a VALUES ('x;y')"},
+		},
+		{
+			name:    "delimiter in double quotes",
+			content: `INSERT INTO a VALUES ("x;y");`,
+			want:    []string{`INSERT INTO a VALUES ("x;y")`},
+		},
+		{
+			name:    "delimiter in backticks",
+			content: "CREATE TABLE `a;b` (id INT);",
+			want:    []string{"CREATE TABLE `a;b` (id INT)"},
+		},
+		{
+			name:    "doubled quote",
+			content: "INSERT INTO a VALUES ('it''s; fine');",
+			want:    []string{"INSERT INTO a VALUES ('it''s; fine')"},
+		},
+		{
+			name:    "mysql backslash escape",
+			dialect: MySQL,
+			content: `INSERT INTO a VALUES ('x\';y'); DELETE FROM a;`,
+			want:    []string{`INSERT INTO a VALUES ('x\';y')`, "DELETE FROM a"},
+		},
+		{
+			name:    "mysql backslash in backticks",
+			dialect: MySQL,
+			content: "SELECT `a\\`; DELETE FROM a;",
+			want:    []string{"SELECT `a\\`", "DELETE FROM a"},
+		},
+		{
+			name:    "postgres backslash is literal",
+			dialect: Postgres,
+			content: `INSERT INTO a VALUES ('C:\'); DELETE FROM a;`,
+			want:    []string{`INSERT INTO a VALUES ('C:\')`, "DELETE FROM a"},
+		},
+		{
+			name:    "postgres escape string",
+			dialect: Postgres,
+			content: `INSERT INTO a VALUES (E'x\';y'); DELETE FROM a;`,
+			want:    []string{`INSERT INTO a VALUES (E'x\';y')`, "DELETE FROM a"},
+		},
+		{
+			name:    "sqlite backslash is literal",
+			dialect: SQLite,
+			content: `INSERT INTO a VALUES ('C:\'); DELETE FROM a;`,
+			want:    []string{`INSERT INTO a VALUES ('C:\')`, "DELETE FROM a"},
+		},
+		{
+			name:    "postgres dollar quotes",
+			dialect: Postgres,
+			content: "CREATE FUNCTION f() RETURNS trigger AS $$\nBEGIN NEW.a = 1; RETURN NEW; END;\n$$ LANGUAGE plpgsql;\nDELETE FROM a;",
+			want: []string{
+				"CREATE FUNCTION f() RETURNS trigger AS $$\nBEGIN NEW.a = 1; RETURN NEW; END;\n$$ LANGUAGE plpgsql",
+				"DELETE FROM a",
+			},
+		},
+		{
+			name:    "postgres tagged dollar quotes",
+			dialect: Postgres,
+			content: "DO $body$ BEGIN PERFORM '$$;'; END; $body$;",
+			want:    []string{"DO $body$ BEGIN PERFORM '$$;'; END; $body$"},
+		},
+		{
+			name:    "postgres positional parameter",
+			dialect: Postgres,
+			content: "PREPARE p AS SELECT $1; DELETE FROM a;",
+			want:    []string{"PREPARE p AS SELECT $1", "DELETE FROM a"},
+		},
+		{
+			name:    "dollar quotes only in postgres",
+			dialect: MySQL,
+			content: "SELECT '$$'; SELECT $$;",
+			want:    []string{"SELECT '$$'", "SELECT $$"},
+		},
+		{
+			name:    "line comments",
+			content: "-- drop a; or not\nDELETE FROM a; -- trailing; comment\nDELETE FROM b;",
+			want:    []string{"DELETE FROM a", "DELETE FROM b"},
+		},
+		{
+			name:    "double dash without space is not a comment",
+			content: "SELECT 1--2;",
+			want:    []string{"SELECT 1--2"},
+		},
+		{
+			name:    "comment at end of input",
+			content: "DELETE FROM a;\n--",
+			want:    []string{"DELETE FROM a"},
+		},
+		{
+			name:    "block comments",
+			content: "DELETE /* a; b */ FROM a;",
+			want:    []string{"DELETE   FROM a"},
+		},
+		{
+			name:    "executable comments and hints are kept",
+			content: "CREATE TABLE a (id INT) /*! ENGINE=InnoDB; */;\nSELECT /*+ NO_INDEX(a) */ id FROM a;",
+			want:    []string{"CREATE TABLE a (id INT) /*! ENGINE=InnoDB; */", "SELECT /*+ NO_INDEX(a) */ id FROM a"},
+		},
+		{
+			name:    "comment markers in strings",
+			content: "INSERT INTO a VALUES ('-- x', '/* y */');",
+			want:    []string{"INSERT INTO a VALUES ('-- x', '/* y */')"},
+		},
+		{
+			name: "delimiter blocks",
+			content: "DELIMITER $$\n" +
+				"CREATE TRIGGER t BEFORE UPDATE ON a FOR EACH ROW\nBEGIN\n  SET NEW.b = 1;\n  SET NEW.c = 2;\nEND$$\n" +
+				"DELIMITER ;\n" +
+				"DELETE FROM a;",
+			want: []string{
+				"CREATE TRIGGER t BEFORE UPDATE ON a FOR EACH ROW\nBEGIN\n  SET NEW.b = 1;\n  SET NEW.c = 2;\nEND",
+				"DELETE FROM a",
+			},
+		},
+		{
+			name:    "indented lower case delimiter",
+			content: "  delimiter //\nSELECT 1; SELECT 2//\ndelimiter ;\nSELECT 3;",
+			want:    []string{"SELECT 1; SELECT 2", "SELECT 3"},
+		},
+		{
+			name:    "delimiter word inside a statement",
+			content: "SELECT delimiter FROM a;",
+			want:    []string{"SELECT delimiter FROM a"},
+		},
+		{
+			name:    "empty input",
+			content: "  \n-- only a comment\n",
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialect := tt.dialect
+			if dialect == "" {
+				dialect = MySQL
+			}
+			got, err := dialect.splitStatements(tt.content)
+			if err != nil {
+				t.Fatalf("splitStatements() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitStatements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitStatementsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		content string
+		wantErr string
+	}{
+		{"unterminated single quote", MySQL, "SELECT 'abc;", "line 1: unterminated ' quoted string"},
+		{"unterminated double quote", MySQL, "SELECT 1;\nSELECT \"abc;", "line 2: unterminated \" quoted string"},
+		{"unterminated backtick", MySQL, "SELECT `abc;", "unterminated ` quoted string"},
+		{"escaped closing quote", MySQL, `SELECT 'abc\';`, "unterminated ' quoted string"},
+		{"unterminated block comment", MySQL, "SELECT 1;\n/* abc;", "line 2: unterminated block comment"},
+		{"unterminated dollar quote", Postgres, "DO $$ BEGIN; END;", "line 1: unterminated $$ quoted string"},
+		{"unterminated tagged dollar quote", Postgres, "DO $fn$ BEGIN; END; $$;", "unterminated $fn$ quoted string"},
+		{"delimiter inside statement", MySQL, "SELECT 1\nDELIMITER $$\n", "line 2: DELIMITER must not appear inside a statement"},
+		{"delimiter without token", MySQL, "DELIMITER\nSELECT 1;", "line 1: DELIMITER requires a delimiter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.dialect.splitStatements(tt.content)
+			if err == nil {
+				t.Fatalf("splitStatements() succeeded, want error %q", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("splitStatements() error = %q, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
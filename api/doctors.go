@@ -0,0 +1,103 @@
+package main
+
+import (
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type doctorRequest struct {
+	Name           string   `json:"name" binding:"notblank,max=255"`
+	Role           string   `json:"role" binding:"notblank,max=50"`
+	Email          string   `json:"email" binding:"required,email,max=255"`
+	Phone          string   `json:"phone" binding:"max=50"`
+	Department     string   `json:"department" binding:"max=255"`
+	Specialization string   `json:"specialization" binding:"max=255"`
+	Bio            string   `json:"bio" binding:"max=5000"`
+	Education      []string `json:"education" binding:"max=20,dive,notblank,max=255"`
+	Experience     []string `json:"experience" binding:"max=20,dive,notblank,max=255"`
+	ProfileImage   string   `json:"profileImage" binding:"max=255"`
+}
+
+func (r doctorRequest) toDoctor() models.Doctor {
+	return models.Doctor{
+		Name:           strings.TrimSpace(r.Name),
+		Role:           r.Role,
+		Email:          r.Email,
+		Phone:          r.Phone,
+		Department:     r.Department,
+		Specialization: r.Specialization,
+		Bio:            r.Bio,
+		Education:      r.Education,
+		Experience:     r.Experience,
+		ProfileImage:   r.ProfileImage,
+	}
+}
+
+// --- Doctor Handlers ---
+func (s *server) getDoctors(c *gin.Context) {
+	q := listQuery{c: c}
+	filter := store.DoctorFilter{
+		Department:     q.text("department"),
+		Specialization: q.text("specialization"),
+	}
+	page := q.page(store.DoctorSortKeys)
+	if !q.ok() {
+		return
+	}
+
+	doctors, total, err := s.store.Doctors.List(c.Request.Context(), filter, page)
+	if err != nil {
+		respondStoreError(c, err, "Doctor")
+		return
+	}
+
+	respondPage(c, page, total, doctors)
+}
+
+func (s *server) getDoctor(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, codeInvalidRequest, "Invalid ID")
+		return
+	}
+
+	doctor, err := s.store.Doctors.Get(c.Request.Context(), id)
+	if err != nil {
+		respondStoreError(c, err, "Doctor")
+		return
+	}
+
+	c.JSON(http.StatusOK, doctor)
+}
+
+func (s *server) updateDoctor(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, codeInvalidRequest, "Invalid ID")
+		return
+	}
+
+	var doctorData doctorRequest
+	if !bindJSON(c, &doctorData) {
+		return
+	}
+
+	doctor := doctorData.toDoctor()
+	doctor.ID = id
+	if err := s.store.Doctors.Update(c.Request.Context(), &doctor); err != nil {
+		if errors.Is(err, store.ErrConflict) {
+			respondConflict(c, "doctor", "email")
+			return
+		}
+		respondStoreError(c, err, "Doctor")
+		return
+	}
+
+	c.JSON(http.StatusOK, doctor)
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"carehub-microservice/logging"
+	"carehub-microservice/store"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// Error codes returned in the "code" field of error responses. Clients
+// should branch on these rather than on messages, which may change.
+const (
+	codeInvalidRequest     = "INVALID_REQUEST"
+	codeValidationFailed   = "VALIDATION_FAILED"
+	codeUnauthorized       = "UNAUTHORIZED"
+	codeTokenExpired       = "TOKEN_EXPIRED"
+	codeInvalidCredentials = "INVALID_CREDENTIALS"
+	codeForbidden          = "FORBIDDEN"
+	codeNotFound           = "NOT_FOUND"
+	codeConflict           = "CONFLICT"
+	codeInvalidReference   = "INVALID_REFERENCE"
+	codeTimeout            = "TIMEOUT"
+	codeInternal           = "INTERNAL_ERROR"
+)
+
+// statusClientClosedRequest is the nginx convention for a request the client
+// gave up on before the response was written
+const statusClientClosedRequest = 499
+
+// apiError is the body of every error response, wrapped in an "error" key
+type apiError struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Details []fieldError `json:"details,omitempty"`
+	// RequestID matches the X-Request-ID header and the service log
+	RequestID string `json:"requestId,omitempty"`
+	// RequiredRoles lists the roles allowed to make a FORBIDDEN call
+	RequiredRoles []string `json:"requiredRoles,omitempty"`
+}
+
+// fieldError describes a problem with one field of the request body
+type fieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// abortWithError writes an error response and stops the handler chain
+func abortWithError(c *gin.Context, status int, e apiError) {
+	e.RequestID = c.GetString(ctxRequestID)
+	c.AbortWithStatusJSON(status, gin.H{"error": e})
+}
+
+// respondError writes an error response without details
+func respondError(c *gin.Context, status int, code, message string) {
+	abortWithError(c, status, apiError{Code: code, Message: message})
+}
+
+// respondInvalid writes a validation error listing the offending fields
+func respondInvalid(c *gin.Context, message string, details ...fieldError) {
+	abortWithError(c, http.StatusUnprocessableEntity, apiError{Code: codeValidationFailed, Message: message, Details: details})
+}
+
+// respondConflict writes the 409 for a unique field of the request body
+// whose value another record of the kind named by resource already holds
+func respondConflict(c *gin.Context, resource, field string) {
+	abortWithError(c, http.StatusConflict, apiError{
+		Code:    codeConflict,
+		Message: "A " + resource + " with this " + field + " already exists",
+		Details: []fieldError{{Field: field, Message: "is already taken"}},
+	})
+}
+
+// respondInvalidReference reports that field of the request body names a
+// record that does not exist
+func respondInvalidReference(c *gin.Context, field, message string) {
+	abortWithError(c, http.StatusUnprocessableEntity, apiError{
+		Code:    codeInvalidReference,
+		Message: "Request refers to a record that does not exist",
+		Details: []fieldError{{Field: field, Message: message}},
+	})
+}
+
+// respondStoreError maps an error from the store to a response. resource
+// names the record the handler was working on, such as "Patient". Anything
+// unexpected is logged and reported as a bare 500, since driver messages can
+// reveal the schema or quote patient data.
+func respondStoreError(c *gin.Context, err error, resource string) {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		respondError(c, http.StatusNotFound, codeNotFound, resource+" not found")
+	case errors.Is(err, store.ErrConflict):
+		respondError(c, http.StatusConflict, codeConflict, resource+" conflicts with an existing record")
+	case errors.Is(err, store.ErrInvalidReference):
+		respondError(c, http.StatusUnprocessableEntity, codeInvalidReference, resource+" refers to a record that does not exist")
+	case errors.Is(err, context.Canceled) || errors.Is(c.Request.Context().Err(), context.Canceled):
+		// The client went away, so there is nobody to send a body to and
+		// nothing wrong with the server
+		logging.FromContext(c.Request.Context()).Debug("Request cancelled by the client", "error", err)
+		c.AbortWithStatus(statusClientClosedRequest)
+	case errors.Is(err, context.DeadlineExceeded):
+		logging.FromContext(c.Request.Context()).Warn("Query timed out", "error", err)
+		respondError(c, http.StatusServiceUnavailable, codeTimeout, "The request took too long, please try again")
+	default:
+		respondInternalError(c, err)
+	}
+}
+
+// respondInternalError logs err and writes a 500 that does not reveal it
+func respondInternalError(c *gin.Context, err error) {
+	logging.FromContext(c.Request.Context()).Error("Request failed", "error", err)
+	respondError(c, http.StatusInternalServerError, codeInternal, "Internal server error")
+}
+
+// bindJSON decodes the request body into obj and checks its binding rules.
+// It writes a 400 if the body is not valid JSON for obj, or a 422 listing
+// every broken rule, and returns false in either case.
+func bindJSON(c *gin.Context, obj interface{}) bool {
+	err := c.ShouldBindJSON(obj)
+	if err == nil {
+		return true
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		respondInvalid(c, "Request body has invalid fields", validationDetails(validationErrs)...)
+	} else {
+		respondBindError(c, err)
+	}
+	return false
+}
+
+// respondBindError describes a body decoding error without exposing Go type
+// names, pointing at the offending field where the decoder reports one.
+func respondBindError(c *gin.Context, err error) {
+	e := apiError{Code: codeInvalidRequest, Message: "Request body is not valid JSON"}
+
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		e.Message = "Request body has a field of the wrong type"
+		e.Details = []fieldError{{Field: typeErr.Field, Message: "must be " + jsonTypeName(typeErr.Type.Kind())}}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		e.Message = "Request body has an unknown field"
+		e.Details = []fieldError{{Field: field, Message: "is not a known field"}}
+	}
+	abortWithError(c, http.StatusBadRequest, e)
+}
+
+// jsonTypeName describes a Go kind in JSON terms
+func jsonTypeName(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Bool:
+		return "true or false"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	}
+	return "an object"
+}
+
+// noRoute answers requests for unknown paths in the same format as the API
+func noRoute(c *gin.Context) {
+	respondError(c, http.StatusNotFound, codeNotFound, "Route not found")
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"carehub-microservice/db"
+	"carehub-microservice/logging"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readinessTimeout bounds the database ping made by /readyz
+const readinessTimeout = 2 * time.Second
+
+// dependencyStatus is the state of one dependency in the /readyz response
+type dependencyStatus struct {
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	LatencyMS *int64 `json:"latencyMs,omitempty"`
+	Version   *int64 `json:"version,omitempty"`
+}
+
+// healthz is the liveness probe. It checks no dependencies, so an outage of
+// the database makes the service unready rather than getting it restarted.
+func (s *server) healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// readyz is the readiness probe. It answers 503 until migrations have been
+// applied and whenever the database does not respond to a ping.
+func (s *server) readyz(c *gin.Context) {
+	checks := map[string]dependencyStatus{}
+	ready := true
+
+	// The memory driver has neither a database nor migrations to wait for
+	if s.db != nil {
+		database := s.checkDatabase(c.Request.Context())
+		migrations := checkMigrations()
+		checks["database"] = database
+		checks["migrations"] = migrations
+		ready = database.Status == "up" && migrations.Status == db.MigrationsComplete
+	}
+
+	status, code := "ready", http.StatusOK
+	if !ready {
+		status, code = "unavailable", http.StatusServiceUnavailable
+	}
+	c.JSON(code, gin.H{"status": status, "checks": checks})
+}
+
+func (s *server) checkDatabase(ctx context.Context) dependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	start := time.Now()
+	if err := s.db.PingContext(ctx); err != nil {
+		// The driver error can name hosts and users, so it is only logged
+		logging.FromContext(ctx).Warn("Readiness check: database ping failed", "error", err)
+		message := "ping failed"
+		if errors.Is(err, context.DeadlineExceeded) {
+			message = "ping timed out"
+		}
+		return dependencyStatus{Status: "down", Error: message}
+	}
+	latency := time.Since(start).Milliseconds()
+	return dependencyStatus{Status: "up", LatencyMS: &latency}
+}
+
+func checkMigrations() dependencyStatus {
+	progress := db.CurrentMigrationProgress()
+	status := dependencyStatus{Status: progress.State}
+	switch progress.State {
+	case db.MigrationsComplete:
+		status.Version = &progress.Version
+	case db.MigrationsFailed:
+		status.Error = "migrations failed, see the service log"
+	}
+	return status
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"carehub-microservice/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// --- Hospital Handler ---
+func (s *server) getHospital(c *gin.Context) {
+	// Contact details come from the system settings, the description is fixed
+	settings := s.currentSettings(c.Request.Context())
+	hospital := models.Hospital{
+		Name:        settings.HospitalName,
+		Address:     settings.Address,
+		Email:       settings.ContactEmail,
+		Phone:       settings.ContactPhone,
+		Description: "Modern hospital with the best medical team in the region.",
+	}
+
+	c.JSON(http.StatusOK, hospital)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"carehub-microservice/store"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// --- Intern Handlers ---
+func (s *server) getInterns(c *gin.Context) {
+	q := listQuery{c: c}
+	filter := store.InternFilter{Department: q.text("department")}
+	page := q.page(store.InternSortKeys)
+	if !q.ok() {
+		return
+	}
+
+	interns, total, err := s.store.Interns.List(c.Request.Context(), filter, page)
+	if err != nil {
+		respondStoreError(c, err, "Intern")
+		return
+	}
+
+	respondPage(c, page, total, interns)
+}
+
+func (s *server) getIntern(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, codeInvalidRequest, "Invalid ID")
+		return
+	}
+
+	intern, err := s.store.Interns.Get(c.Request.Context(), id)
+	if err != nil {
+		respondStoreError(c, err, "Intern")
+		return
+	}
+
+	c.JSON(http.StatusOK, intern)
+}
@@ -0,0 +1,106 @@
+package logging
+
+import (
+	"context"
+	"regexp"
+
+	"golang.org/x/exp/slog"
+)
+
+// Redacted replaces protected values in log records
+const Redacted = "[REDACTED]"
+
+// phiKeys are the normalized attribute keys whose values are always redacted
+var phiKeys = map[string]bool{
+	"name":        true,
+	"firstname":   true,
+	"lastname":    true,
+	"fullname":    true,
+	"patientname": true,
+	"email":       true,
+	"dateofbirth": true,
+	"dob":         true,
+	"birthdate":   true,
+	"address":     true,
+	"phone":       true,
+}
+
+// phiPatterns catch protected values that end up inside free text, such as
+// a database error quoting the duplicate email or a date of birth.
+var phiPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),
+	regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}\b`),
+	regexp.MustCompile(`\b\d{1,2}/\d{1,2}/\d{4}\b`),
+}
+
+// RedactString replaces every email address and date in s
+func RedactString(s string) string {
+	for _, pattern := range phiPatterns {
+		s = pattern.ReplaceAllString(s, Redacted)
+	}
+	return s
+}
+
+// redactingHandler removes protected health information from records
+// before passing them on. Attributes with a PHI key are replaced entirely;
+// other strings, errors and the message are scrubbed with phiPatterns.
+type redactingHandler struct {
+	next slog.Handler
+}
+
+// NewRedactingHandler wraps next so that no record reaches it unredacted
+func NewRedactingHandler(next slog.Handler) slog.Handler {
+	return &redactingHandler{next: next}
+}
+
+func (h *redactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *redactingHandler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, RedactString(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(a))
+		return true
+	})
+	return h.next.Handle(ctx, redacted)
+}
+
+func (h *redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = redactAttr(a)
+	}
+	return &redactingHandler{next: h.next.WithAttrs(redacted)}
+}
+
+func (h *redactingHandler) WithGroup(name string) slog.Handler {
+	return &redactingHandler{next: h.next.WithGroup(name)}
+}
+
+func redactAttr(a slog.Attr) slog.Attr {
+	if phiKeys[normalizeKey(a.Key)] {
+		return slog.String(a.Key, Redacted)
+	}
+
+	value := a.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, RedactString(value.String()))
+	case slog.KindGroup:
+		group := value.Group()
+		redacted := make([]any, len(group))
+		for i, ga := range group {
+			redacted[i] = redactAttr(ga)
+		}
+		return slog.Group(a.Key, redacted...)
+	case slog.KindAny:
+		// Errors and other values are rendered as text and scrubbed; structs
+		// could hold anything, so they are never logged as they are
+		if err, ok := value.Any().(error); ok {
+			return slog.String(a.Key, RedactString(err.Error()))
+		}
+		return slog.String(a.Key, Redacted)
+	}
+	return slog.Attr{Key: a.Key, Value: value}
+}
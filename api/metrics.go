@@ -0,0 +1,66 @@
+package main
+
+import (
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type healthMetricRequest struct {
+	Type  string  `json:"type" binding:"notblank,max=100"`
+	Value float64 `json:"value" binding:"gt=0,lt=100000000"`
+	Unit  string  `json:"unit" binding:"notblank,max=50"`
+}
+
+// Health Metric Handlers
+func (s *server) getPatientHealthMetrics(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, codeInvalidRequest, "Invalid patient ID format")
+		return
+	}
+
+	metrics, err := s.store.Metrics.ListByPatient(c.Request.Context(), id)
+	if err != nil {
+		respondStoreError(c, err, "Health metric")
+		return
+	}
+
+	c.JSON(http.StatusOK, metrics)
+}
+
+func (s *server) recordHealthMetric(c *gin.Context) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, codeInvalidRequest, "Invalid patient ID format")
+		return
+	}
+
+	var metricData healthMetricRequest
+	if !bindJSON(c, &metricData) {
+		return
+	}
+
+	newMetric := models.HealthMetric{
+		PatientID: patientID,
+		Type:      strings.TrimSpace(metricData.Type),
+		Value:     metricData.Value,
+		Unit:      strings.TrimSpace(metricData.Unit),
+	}
+	if err := s.store.Metrics.Create(c.Request.Context(), &newMetric); err != nil {
+		// The patient comes from the path, so an unknown one is a missing resource
+		if errors.Is(err, store.ErrInvalidReference) {
+			respondError(c, http.StatusNotFound, codeNotFound, "Patient not found")
+			return
+		}
+		respondStoreError(c, err, "Health metric")
+		return
+	}
+
+	c.JSON(http.StatusCreated, newMetric)
+}
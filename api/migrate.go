@@ -0,0 +1,98 @@
+package main
+
+import (
+	"carehub-microservice/config"
+	"carehub-microservice/db"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+const migrateUsage = `Usage: carehub [-config file] migrate <command>
+
+Commands:
+  up              Apply all pending migrations
+  down [steps]    Revert the last applied migration, or the last <steps> migrations
+  status          List migrations and whether they are applied
+  to <version>    Apply or revert migrations until <version> is the latest applied (0 reverts all)
+`
+
+// runMigrate implements the "migrate" subcommand and returns the process exit code
+func runMigrate(cfg config.Config, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	if cfg.Database.Driver == config.DriverMemory {
+		fmt.Fprintln(os.Stderr, "The memory driver has no schema to migrate")
+		return 2
+	}
+
+	if err := db.Open(cfg.Database); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		return 1
+	}
+	defer db.CloseDB()
+
+	var err error
+	switch args[0] {
+	case "up":
+		err = db.MigrateTo(db.DB, -1)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				fmt.Fprintf(os.Stderr, "Invalid number of steps: %s\n", args[1])
+				return 2
+			}
+		}
+		err = db.MigrateDown(db.DB, steps)
+	case "to":
+		if len(args) < 2 {
+			fmt.Fprint(os.Stderr, migrateUsage)
+			return 2
+		}
+		version, perr := strconv.ParseInt(args[1], 10, 64)
+		if perr != nil || version < 0 {
+			fmt.Fprintf(os.Stderr, "Invalid version: %s\n", args[1])
+			return 2
+		}
+		err = db.MigrateTo(db.DB, version)
+	case "status":
+		err = printMigrationStatus()
+	default:
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Migration failed: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func printMigrationStatus() error {
+	status, err := db.Status(db.DB)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT\tDOWN")
+	for _, m := range status {
+		state, appliedAt := "pending", "-"
+		if m.Applied {
+			state, appliedAt = "applied", m.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		down := "no"
+		if m.HasDown {
+			down = "yes"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", m.Version, m.Name, state, appliedAt, down)
+	}
+	return w.Flush()
+}
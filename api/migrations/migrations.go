@@ -0,0 +1,14 @@
+// Package migrations embeds the SQL migration files into the binary so the
+// service can run from any working directory.
+//
+// Each supported database has its own directory of migrations, named after
+// its driver. The sets must stay equivalent: a migration added to one
+// directory needs a matching file, with the same version, in every other.
+package migrations
+
+import "embed"
+
+// FS holds the NNN_name.up.sql and NNN_name.down.sql files of every dialect
+//
+//go:embed mysql/*.sql postgres/*.sql sqlite/*.sql
+var FS embed.FS
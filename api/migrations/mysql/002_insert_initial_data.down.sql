@@ -0,0 +1,26 @@
+-- Remove Interns
+DELETE FROM interns WHERE email IN ('alex.green@carehub.com', 'priya.patel@carehub.com');
+
+-- Remove Blogs
+DELETE FROM blogs WHERE title IN ('Heart Health Tips', 'Understanding Migraines');
+
+-- Remove Doctors
+DELETE FROM doctors WHERE email IN ('jane.smith@carehub.com', 'robert.chen@carehub.com', 'maria.rodriguez@carehub.com', 'james.wilson@carehub.com');
+
+-- Remove Patients (their appointments and health metrics cascade)
+DELETE FROM patients WHERE email IN ('john.doe@example.com', 'jane.smith@example.com');
+
+-- Remove Users
+DELETE FROM users WHERE username IN ('admin', 'doctor', 'superadmin', 'nurse', 'intern', 'patient');
+
+-- Restart the ID counters after the highest remaining ID. The up migration
+-- refers to the seed rows by ID, so re-applying it on emptied tables needs
+-- them to get IDs 1 and 2 again. MySQL never lowers the counter below
+-- MAX(id) + 1, so resetting it to 1 does exactly that.
+ALTER TABLE users AUTO_INCREMENT = 1;
+ALTER TABLE patients AUTO_INCREMENT = 1;
+ALTER TABLE doctors AUTO_INCREMENT = 1;
+ALTER TABLE appointments AUTO_INCREMENT = 1;
+ALTER TABLE health_metrics AUTO_INCREMENT = 1;
+ALTER TABLE blogs AUTO_INCREMENT = 1;
+ALTER TABLE interns AUTO_INCREMENT = 1;
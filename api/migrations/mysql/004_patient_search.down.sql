@@ -0,0 +1,12 @@
+-- Drop patient search keys and indexes
+DROP INDEX idx_patients_first_name ON patients;
+DROP INDEX idx_patients_last_name ON patients;
+DROP INDEX idx_patients_first_name_soundex ON patients;
+DROP INDEX idx_patients_last_name_soundex ON patients;
+DROP INDEX idx_patients_phone_digits ON patients;
+DROP INDEX idx_patients_date_of_birth ON patients;
+
+ALTER TABLE patients
+    DROP COLUMN first_name_soundex,
+    DROP COLUMN last_name_soundex,
+    DROP COLUMN phone_digits;
@@ -0,0 +1,16 @@
+-- Add patient search keys and indexes
+-- The Soundex codes and phone digits are computed by the service when a
+-- patient is saved; rows that predate them are filled in on startup.
+ALTER TABLE patients
+    ADD COLUMN first_name_soundex VARCHAR(4),
+    ADD COLUMN last_name_soundex VARCHAR(4),
+    ADD COLUMN phone_digits VARCHAR(50);
+
+-- Name prefixes are matched with LIKE, which the case-insensitive collation
+-- answers from a plain index; email already has its unique index.
+CREATE INDEX idx_patients_first_name ON patients (first_name);
+CREATE INDEX idx_patients_last_name ON patients (last_name);
+CREATE INDEX idx_patients_first_name_soundex ON patients (first_name_soundex);
+CREATE INDEX idx_patients_last_name_soundex ON patients (last_name_soundex);
+CREATE INDEX idx_patients_phone_digits ON patients (phone_digits);
+CREATE INDEX idx_patients_date_of_birth ON patients (date_of_birth);
@@ -0,0 +1,88 @@
+-- Postgres version of mysql/001_create_tables.up.sql.
+-- SERIAL replaces AUTO_INCREMENT, JSON documents are stored as JSONB, and
+-- blogs.updated_at is set by the application instead of ON UPDATE
+-- CURRENT_TIMESTAMP.
+
+-- Create Users table
+CREATE TABLE IF NOT EXISTS users (
+    id SERIAL PRIMARY KEY,
+    username VARCHAR(255) NOT NULL UNIQUE,
+    password VARCHAR(255) NOT NULL,
+    role VARCHAR(50) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    phone VARCHAR(50),
+    department VARCHAR(255)
+);
+
+-- Create Patients table
+CREATE TABLE IF NOT EXISTS patients (
+    id SERIAL PRIMARY KEY,
+    first_name VARCHAR(255) NOT NULL,
+    last_name VARCHAR(255) NOT NULL,
+    date_of_birth DATE NOT NULL,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    phone VARCHAR(50),
+    address TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create Appointments table
+CREATE TABLE IF NOT EXISTS appointments (
+    id SERIAL PRIMARY KEY,
+    patient_id INTEGER NOT NULL,
+    date_time TIMESTAMP NOT NULL,
+    description TEXT,
+    status VARCHAR(50) NOT NULL,
+    doctor VARCHAR(255) NOT NULL,
+    FOREIGN KEY (patient_id) REFERENCES patients(id) ON DELETE CASCADE
+);
+
+-- Create Health Metrics table
+CREATE TABLE IF NOT EXISTS health_metrics (
+    id SERIAL PRIMARY KEY,
+    patient_id INTEGER NOT NULL,
+    type VARCHAR(100) NOT NULL,
+    value DECIMAL(10,2) NOT NULL,
+    unit VARCHAR(50) NOT NULL,
+    recorded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (patient_id) REFERENCES patients(id) ON DELETE CASCADE
+);
+
+-- Create Doctors table
+CREATE TABLE IF NOT EXISTS doctors (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    role VARCHAR(50) NOT NULL,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    phone VARCHAR(50),
+    department VARCHAR(255),
+    specialization VARCHAR(255),
+    bio TEXT,
+    education JSONB,
+    experience JSONB,
+    profile_image VARCHAR(255)
+);
+
+-- Create Blogs table
+CREATE TABLE IF NOT EXISTS blogs (
+    id SERIAL PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    content TEXT NOT NULL,
+    excerpt TEXT,
+    cover_image VARCHAR(255),
+    author_id INTEGER NOT NULL,
+    author_name VARCHAR(255) NOT NULL,
+    published_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    tags JSONB,
+    FOREIGN KEY (author_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create Interns table
+CREATE TABLE IF NOT EXISTS interns (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    department VARCHAR(255) NOT NULL
+);
@@ -0,0 +1,25 @@
+-- Remove Interns
+DELETE FROM interns WHERE email IN ('alex.green@carehub.com', 'priya.patel@carehub.com');
+
+-- Remove Blogs
+DELETE FROM blogs WHERE title IN ('Heart Health Tips', 'Understanding Migraines');
+
+-- Remove Doctors
+DELETE FROM doctors WHERE email IN ('jane.smith@carehub.com', 'robert.chen@carehub.com', 'maria.rodriguez@carehub.com', 'james.wilson@carehub.com');
+
+-- Remove Patients (their appointments and health metrics cascade)
+DELETE FROM patients WHERE email IN ('john.doe@example.com', 'jane.smith@example.com');
+
+-- Remove Users
+DELETE FROM users WHERE username IN ('admin', 'doctor', 'superadmin', 'nurse', 'intern', 'patient');
+
+-- Restart the ID sequences after the highest remaining ID. The up migration
+-- refers to the seed rows by ID, so re-applying it on emptied tables needs
+-- them to get IDs 1 and 2 again.
+SELECT setval(pg_get_serial_sequence('users', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM users;
+SELECT setval(pg_get_serial_sequence('patients', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM patients;
+SELECT setval(pg_get_serial_sequence('doctors', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM doctors;
+SELECT setval(pg_get_serial_sequence('appointments', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM appointments;
+SELECT setval(pg_get_serial_sequence('health_metrics', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM health_metrics;
+SELECT setval(pg_get_serial_sequence('blogs', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM blogs;
+SELECT setval(pg_get_serial_sequence('interns', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM interns;
@@ -0,0 +1,2 @@
+-- Drop System Settings table
+DROP TABLE IF EXISTS system_settings;
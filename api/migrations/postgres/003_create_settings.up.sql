@@ -0,0 +1,10 @@
+-- Create System Settings table
+-- Every update inserts a new row, so the table doubles as the version history.
+-- The effective settings are the defaults overlaid with the latest row.
+CREATE TABLE IF NOT EXISTS system_settings (
+    version SERIAL PRIMARY KEY,
+    data JSONB NOT NULL,
+    updated_by INT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (updated_by) REFERENCES users(id) ON DELETE SET NULL
+);
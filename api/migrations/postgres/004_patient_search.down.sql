@@ -0,0 +1,13 @@
+-- Drop patient search keys and indexes
+DROP INDEX IF EXISTS idx_patients_first_name_lower;
+DROP INDEX IF EXISTS idx_patients_last_name_lower;
+DROP INDEX IF EXISTS idx_patients_email_lower;
+DROP INDEX IF EXISTS idx_patients_first_name_soundex;
+DROP INDEX IF EXISTS idx_patients_last_name_soundex;
+DROP INDEX IF EXISTS idx_patients_phone_digits;
+DROP INDEX IF EXISTS idx_patients_date_of_birth;
+
+ALTER TABLE patients
+    DROP COLUMN IF EXISTS first_name_soundex,
+    DROP COLUMN IF EXISTS last_name_soundex,
+    DROP COLUMN IF EXISTS phone_digits;
@@ -0,0 +1,17 @@
+-- Add patient search keys and indexes
+-- The Soundex codes and phone digits are computed by the service when a
+-- patient is saved; rows that predate them are filled in on startup.
+ALTER TABLE patients
+    ADD COLUMN first_name_soundex VARCHAR(4),
+    ADD COLUMN last_name_soundex VARCHAR(4),
+    ADD COLUMN phone_digits VARCHAR(50);
+
+-- Prefixes are matched with LIKE, which only uses an index built with
+-- text_pattern_ops unless the database collation is C.
+CREATE INDEX IF NOT EXISTS idx_patients_first_name_lower ON patients (LOWER(first_name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_patients_last_name_lower ON patients (LOWER(last_name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_patients_email_lower ON patients (LOWER(email));
+CREATE INDEX IF NOT EXISTS idx_patients_first_name_soundex ON patients (first_name_soundex);
+CREATE INDEX IF NOT EXISTS idx_patients_last_name_soundex ON patients (last_name_soundex);
+CREATE INDEX IF NOT EXISTS idx_patients_phone_digits ON patients (phone_digits text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_patients_date_of_birth ON patients (date_of_birth);
@@ -0,0 +1,8 @@
+-- Drop tables in reverse dependency order
+DROP TABLE IF EXISTS interns;
+DROP TABLE IF EXISTS blogs;
+DROP TABLE IF EXISTS doctors;
+DROP TABLE IF EXISTS health_metrics;
+DROP TABLE IF EXISTS appointments;
+DROP TABLE IF EXISTS patients;
+DROP TABLE IF EXISTS users;
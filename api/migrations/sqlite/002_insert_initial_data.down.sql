@@ -0,0 +1,25 @@
+-- Remove Interns
+DELETE FROM interns WHERE email IN ('alex.green@carehub.com', 'priya.patel@carehub.com');
+
+-- Remove Blogs
+DELETE FROM blogs WHERE title IN ('Heart Health Tips', 'Understanding Migraines');
+
+-- Remove Doctors
+DELETE FROM doctors WHERE email IN ('jane.smith@carehub.com', 'robert.chen@carehub.com', 'maria.rodriguez@carehub.com', 'james.wilson@carehub.com');
+
+-- Remove Patients (their appointments and health metrics cascade)
+DELETE FROM patients WHERE email IN ('john.doe@example.com', 'jane.smith@example.com');
+
+-- Remove Users
+DELETE FROM users WHERE username IN ('admin', 'doctor', 'superadmin', 'nurse', 'intern', 'patient');
+
+-- Restart the ID counters after the highest remaining ID. The up migration
+-- refers to the seed rows by ID, so re-applying it on emptied tables needs
+-- them to get IDs 1 and 2 again.
+UPDATE sqlite_sequence SET seq = (SELECT COALESCE(MAX(id), 0) FROM users) WHERE name = 'users';
+UPDATE sqlite_sequence SET seq = (SELECT COALESCE(MAX(id), 0) FROM patients) WHERE name = 'patients';
+UPDATE sqlite_sequence SET seq = (SELECT COALESCE(MAX(id), 0) FROM doctors) WHERE name = 'doctors';
+UPDATE sqlite_sequence SET seq = (SELECT COALESCE(MAX(id), 0) FROM appointments) WHERE name = 'appointments';
+UPDATE sqlite_sequence SET seq = (SELECT COALESCE(MAX(id), 0) FROM health_metrics) WHERE name = 'health_metrics';
+UPDATE sqlite_sequence SET seq = (SELECT COALESCE(MAX(id), 0) FROM blogs) WHERE name = 'blogs';
+UPDATE sqlite_sequence SET seq = (SELECT COALESCE(MAX(id), 0) FROM interns) WHERE name = 'interns';
@@ -0,0 +1,12 @@
+-- Drop patient search keys and indexes
+DROP INDEX IF EXISTS idx_patients_first_name_lower;
+DROP INDEX IF EXISTS idx_patients_last_name_lower;
+DROP INDEX IF EXISTS idx_patients_email_lower;
+DROP INDEX IF EXISTS idx_patients_first_name_soundex;
+DROP INDEX IF EXISTS idx_patients_last_name_soundex;
+DROP INDEX IF EXISTS idx_patients_phone_digits;
+DROP INDEX IF EXISTS idx_patients_date_of_birth;
+
+ALTER TABLE patients DROP COLUMN first_name_soundex;
+ALTER TABLE patients DROP COLUMN last_name_soundex;
+ALTER TABLE patients DROP COLUMN phone_digits;
@@ -0,0 +1,133 @@
+// Package models holds the domain types shared by the HTTP handlers and the
+// storage backends.
+package models
+
+import "time"
+
+type Patient struct {
+	ID          int       `json:"id"`
+	FirstName   string    `json:"firstName"`
+	LastName    string    `json:"lastName"`
+	DateOfBirth string    `json:"dateOfBirth"`
+	Email       string    `json:"email"`
+	Phone       string    `json:"phone"`
+	Address     string    `json:"address"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type Appointment struct {
+	ID          int       `json:"id"`
+	PatientID   int       `json:"patientId"`
+	DateTime    time.Time `json:"dateTime"`
+	Description string    `json:"description"`
+	Status      string    `json:"status"`
+	Doctor      string    `json:"doctor"`
+}
+
+type HealthMetric struct {
+	ID         int       `json:"id"`
+	PatientID  int       `json:"patientId"`
+	Type       string    `json:"type"`
+	Value      float64   `json:"value"`
+	Unit       string    `json:"unit"`
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+type User struct {
+	ID         int    `json:"id"`
+	Username   string `json:"username"`
+	Password   string `json:"-"`
+	Role       string `json:"role"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	Phone      string `json:"phone"`
+	Department string `json:"department"`
+}
+
+type Doctor struct {
+	ID             int      `json:"id"`
+	Name           string   `json:"name"`
+	Role           string   `json:"role"`
+	Email          string   `json:"email"`
+	Phone          string   `json:"phone"`
+	Department     string   `json:"department"`
+	Specialization string   `json:"specialization"`
+	Bio            string   `json:"bio"`
+	Education      []string `json:"education"`
+	Experience     []string `json:"experience"`
+	ProfileImage   string   `json:"profileImage"`
+}
+
+type Blog struct {
+	ID          int      `json:"id"`
+	Title       string   `json:"title"`
+	Content     string   `json:"content"`
+	Excerpt     string   `json:"excerpt"`
+	CoverImage  string   `json:"coverImage"`
+	AuthorId    int      `json:"authorId"`
+	AuthorName  string   `json:"authorName"`
+	PublishedAt string   `json:"publishedAt"`
+	UpdatedAt   string   `json:"updatedAt"`
+	Tags        []string `json:"tags"`
+}
+
+type Intern struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	Department string `json:"department"`
+}
+
+type Hospital struct {
+	Name        string `json:"name"`
+	Address     string `json:"address"`
+	Email       string `json:"email"`
+	Phone       string `json:"phone"`
+	Description string `json:"description"`
+}
+
+// SettingsRecord is one saved version of the system settings. Data holds the
+// JSON document; interpreting it is up to the caller.
+type SettingsRecord struct {
+	Version   int
+	Data      []byte
+	UpdatedBy *int
+	CreatedAt time.Time
+}
+
+type AnalyticsSummary struct {
+	TotalPatients         int `json:"totalPatients"`
+	ActiveAppointments    int `json:"activeAppointments"`
+	CompletedAppointments int `json:"completedAppointments"`
+	ActiveDoctors         int `json:"activeDoctors"`
+	TotalUsers            int `json:"totalUsers"`
+	TotalBlogs            int `json:"totalBlogs"`
+	RecentPatients        int `json:"recentPatients"`
+}
+
+type PeriodCount struct {
+	Period string `json:"period"`
+	Count  int    `json:"count"`
+}
+
+type LabelCount struct {
+	Label string
+	Count int
+}
+
+// Gauges are point-in-time counts exported to the monitoring system
+type Gauges struct {
+	Patients                   int
+	Users                      int
+	AppointmentsScheduledToday int
+}
+
+type Analytics struct {
+	Summary              AnalyticsSummary
+	NewPatients          []PeriodCount
+	AppointmentsByStatus []LabelCount
+	AppointmentsByDoctor []LabelCount
+	MetricsByType        []LabelCount
+	BlogsByAuthor        []LabelCount
+	UsersByRole          []LabelCount
+}
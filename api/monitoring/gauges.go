@@ -0,0 +1,60 @@
+package monitoring
+
+import (
+	"context"
+	"time"
+
+	"carehub-microservice/store"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/exp/slog"
+)
+
+// gaugeTimeout bounds the store query made on every scrape
+const gaugeTimeout = 2 * time.Second
+
+// gaugeCollector reads the domain gauges from the store when Prometheus
+// scrapes, so the values are never stale and cost nothing between scrapes.
+type gaugeCollector struct {
+	analytics store.AnalyticsStore
+
+	patients       *prometheus.Desc
+	users          *prometheus.Desc
+	scheduledToday *prometheus.Desc
+}
+
+func newGaugeCollector(analytics store.AnalyticsStore) *gaugeCollector {
+	return &gaugeCollector{
+		analytics: analytics,
+		patients: prometheus.NewDesc(namespace+"_patients",
+			"Registered patients.", nil, nil),
+		users: prometheus.NewDesc(namespace+"_users",
+			"User accounts, staff and patients alike.", nil, nil),
+		scheduledToday: prometheus.NewDesc(namespace+"_appointments_scheduled_today",
+			"Appointments with status Scheduled during the current UTC day.", nil, nil),
+	}
+}
+
+func (g *gaugeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- g.patients
+	ch <- g.users
+	ch <- g.scheduledToday
+}
+
+// Collect leaves the gauges out of the scrape when the store cannot answer,
+// rather than reporting zeros that would look like real values.
+func (g *gaugeCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), gaugeTimeout)
+	defer cancel()
+
+	dayStart := time.Now().UTC().Truncate(24 * time.Hour)
+	gauges, err := g.analytics.Gauges(ctx, dayStart, dayStart.Add(24*time.Hour))
+	if err != nil {
+		slog.Warn("Failed to read domain gauges for metrics", "error", err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(g.patients, prometheus.GaugeValue, float64(gauges.Patients))
+	ch <- prometheus.MustNewConstMetric(g.users, prometheus.GaugeValue, float64(gauges.Users))
+	ch <- prometheus.MustNewConstMetric(g.scheduledToday, prometheus.GaugeValue, float64(gauges.AppointmentsScheduledToday))
+}
@@ -0,0 +1,115 @@
+// Package monitoring exposes Prometheus metrics for the service: HTTP
+// traffic per route, the database connection pool, the schema migration
+// state and a few domain gauges read from the store on every scrape.
+package monitoring
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"carehub-microservice/db"
+	"carehub-microservice/store"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes every metric defined by the service
+const namespace = "carehub"
+
+// unmatchedRoute labels requests that matched no route, so probing random
+// paths cannot create new time series
+const unmatchedRoute = "unmatched"
+
+// Metrics owns the registry served on /metrics
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight prometheus.Gauge
+}
+
+// New registers the service metrics. database may be nil for the memory
+// driver, in which case no pool or migration metrics are exported.
+func New(analytics store.AnalyticsStore, database *sql.DB) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests handled, by method, route pattern and status code.",
+		}, []string{"method", "route", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time spent handling HTTP requests, by method and route pattern.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		inFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "http_requests_in_flight",
+			Help:      "HTTP requests currently being handled.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.duration,
+		m.inFlight,
+		newGaugeCollector(analytics),
+	)
+
+	if database != nil {
+		m.registry.MustRegister(
+			collectors.NewDBStatsCollector(database, namespace),
+			prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "schema_migration_version",
+				Help:      "Latest schema migration applied at startup, 0 until migrations complete.",
+			}, func() float64 {
+				return float64(db.CurrentMigrationProgress().Version)
+			}),
+			prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "schema_migrations_complete",
+				Help:      "1 once the startup migrations have been applied, 0 while pending, running or failed.",
+			}, func() float64 {
+				if db.CurrentMigrationProgress().State == db.MigrationsComplete {
+					return 1
+				}
+				return 0
+			}),
+		)
+	}
+	return m
+}
+
+// Middleware records the count, status and latency of every request
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		m.inFlight.Inc()
+		defer m.inFlight.Dec()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		m.requests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		m.duration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Handler serves the registry in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"carehub-microservice/store"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// List endpoints return defaultPageLimit records unless the client asks for
+// a different limit, which may not exceed maxPageLimit
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 200
+)
+
+// listQuery reads the query parameters of a list request, collecting a
+// field error for each one that does not parse so that they can all be
+// reported together
+type listQuery struct {
+	c       *gin.Context
+	details []fieldError
+}
+
+func (q *listQuery) invalid(field, message string) {
+	q.details = append(q.details, fieldError{Field: field, Message: message})
+}
+
+// page reads limit, offset and sort. sort is one of sortKeys, prefixed with
+// "-" for descending order.
+func (q *listQuery) page(sortKeys []string) store.Page {
+	p := store.Page{Limit: q.limit(defaultPageLimit, maxPageLimit)}
+	if v := q.c.Query("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			q.invalid("offset", "must be a number no less than 0")
+		}
+		p.Offset = offset
+	}
+	if v := q.c.Query("sort"); v != "" {
+		p.Sort = strings.TrimPrefix(v, "-")
+		p.Desc = p.Sort != v
+		if !contains(sortKeys, p.Sort) {
+			q.invalid("sort", "must be one of "+strings.Join(sortKeys, ", ")+", optionally prefixed with -")
+		}
+	}
+	return p
+}
+
+// limit reads the limit parameter, which must be from 1 to max and is def
+// when not set
+func (q *listQuery) limit(def, max int) int {
+	v := q.c.Query("limit")
+	if v == "" {
+		return def
+	}
+	limit, err := strconv.Atoi(v)
+	if err != nil || limit < 1 || limit > max {
+		q.invalid("limit", fmt.Sprintf("must be a number from 1 to %d", max))
+	}
+	return limit
+}
+
+// text returns the trimmed value of a text filter
+func (q *listQuery) text(name string) string {
+	return strings.TrimSpace(q.c.Query(name))
+}
+
+// id returns the value of a filter on a record ID, or 0 if it is not set
+func (q *listQuery) id(name string) int {
+	v := q.c.Query(name)
+	if v == "" {
+		return 0
+	}
+	id, err := strconv.Atoi(v)
+	if err != nil || id < 1 {
+		q.invalid(name, "must be a positive number")
+	}
+	return id
+}
+
+// date returns the value of a YYYY-MM-DD filter, or the zero time if it is
+// not set
+func (q *listQuery) date(name string) time.Time {
+	v := q.c.Query(name)
+	if v == "" {
+		return time.Time{}
+	}
+	date, err := time.Parse(dateLayout, v)
+	if err != nil {
+		q.invalid(name, "must be a date in YYYY-MM-DD format")
+	}
+	return date
+}
+
+// ok writes a 400 listing the invalid parameters, if there were any, and
+// reports whether the handler should carry on
+func (q *listQuery) ok() bool {
+	if len(q.details) > 0 {
+		abortWithError(q.c, http.StatusBadRequest, apiError{
+			Code:    codeInvalidRequest,
+			Message: "Query has invalid parameters",
+			Details: q.details,
+		})
+		return false
+	}
+	return true
+}
+
+// respondPage writes one page of a list. The body stays a plain array; the
+// total goes in X-Total-Count and links to the neighbouring pages in a Link
+// header (RFC 8288), both of which the CORS setup exposes to the frontend.
+func respondPage(c *gin.Context, p store.Page, total int, records interface{}) {
+	c.Header("X-Total-Count", strconv.Itoa(total))
+
+	var links []string
+	link := func(offset int, rel string) {
+		query := c.Request.URL.Query()
+		query.Set("limit", strconv.Itoa(p.Limit))
+		query.Set("offset", strconv.Itoa(offset))
+		u := url.URL{Path: c.Request.URL.Path, RawQuery: query.Encode()}
+		links = append(links, fmt.Sprintf("<%s>; rel=%q", u.String(), rel))
+	}
+	last := 0
+	if total > 0 {
+		last = (total - 1) / p.Limit * p.Limit
+	}
+	link(0, "first")
+	if p.Offset > 0 {
+		prev := p.Offset - p.Limit
+		if prev < 0 {
+			prev = 0
+		}
+		link(prev, "prev")
+	}
+	if p.Offset+p.Limit < total {
+		link(p.Offset+p.Limit, "next")
+	}
+	link(last, "last")
+	c.Header("Link", strings.Join(links, ", "))
+
+	c.JSON(http.StatusOK, records)
+}
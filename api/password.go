@@ -0,0 +1,54 @@
+package main
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// passwordCost is the bcrypt work factor for newly hashed passwords. Hashes
+// stored with a lower cost are upgraded on the next successful login.
+const passwordCost = 12
+
+// dummyHash is compared against when a username does not exist so that
+// failed logins take the same time whether or not the account exists.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("carehub-dummy-password"), passwordCost)
+
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), passwordCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func isPasswordHash(stored string) bool {
+	return strings.HasPrefix(stored, "$2a$") ||
+		strings.HasPrefix(stored, "$2b$") ||
+		strings.HasPrefix(stored, "$2y$")
+}
+
+// verifyPassword checks password against the stored value. Stored values that
+// are not bcrypt hashes are legacy plaintext rows and are compared in constant
+// time. needsRehash reports whether the stored value should be replaced with
+// a fresh hash now that the plaintext is known to be correct.
+func verifyPassword(stored, password string) (ok bool, needsRehash bool) {
+	if isPasswordHash(stored) {
+		if bcrypt.CompareHashAndPassword([]byte(stored), []byte(password)) != nil {
+			return false, false
+		}
+		cost, err := bcrypt.Cost([]byte(stored))
+		return true, err != nil || cost < passwordCost
+	}
+
+	// Legacy plaintext row
+	ok = subtle.ConstantTimeCompare([]byte(stored), []byte(password)) == 1
+	return ok, ok
+}
+
+// burnPasswordCheck performs a throwaway bcrypt comparison for logins with an
+// unknown username.
+func burnPasswordCheck(password string) {
+	bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"carehub-microservice/models"
+	"carehub-microservice/search"
+	"carehub-microservice/store"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type patientRequest struct {
+	FirstName   string `json:"firstName" binding:"notblank,max=255"`
+	LastName    string `json:"lastName" binding:"notblank,max=255"`
+	DateOfBirth string `json:"dateOfBirth" binding:"pastdate"`
+	Email       string `json:"email" binding:"required,email,max=255"`
+	Phone       string `json:"phone" binding:"max=50"`
+	Address     string `json:"address" binding:"max=1000"`
+}
+
+func (r patientRequest) toPatient() models.Patient {
+	return models.Patient{
+		FirstName:   strings.TrimSpace(r.FirstName),
+		LastName:    strings.TrimSpace(r.LastName),
+		DateOfBirth: r.DateOfBirth,
+		Email:       r.Email,
+		Phone:       r.Phone,
+		Address:     r.Address,
+	}
+}
+
+// respondPatientError maps a failed write, pointing at the email field when
+// it is the unique value that clashed
+func respondPatientError(c *gin.Context, err error) {
+	if errors.Is(err, store.ErrConflict) {
+		respondConflict(c, "patient", "email")
+		return
+	}
+	respondStoreError(c, err, "Patient")
+}
+
+// Patient Handlers
+func (s *server) getPatients(c *gin.Context) {
+	q := listQuery{c: c}
+	filter := store.PatientFilter{
+		FirstName: q.text("firstName"),
+		LastName:  q.text("lastName"),
+		Email:     q.text("email"),
+	}
+	page := q.page(store.PatientSortKeys)
+	if !q.ok() {
+		return
+	}
+
+	patients, total, err := s.store.Patients.List(c.Request.Context(), filter, page)
+	if err != nil {
+		respondStoreError(c, err, "Patient")
+		return
+	}
+
+	respondPage(c, page, total, patients)
+}
+
+// Search returns defaultSearchLimit matches unless the client asks for up to
+// maxSearchLimit. Matches are ranked from at most searchCandidates
+// candidates, which is plenty for a query specific enough to be useful.
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+	searchCandidates   = 500
+)
+
+func (s *server) searchPatients(c *gin.Context) {
+	q := listQuery{c: c}
+	query := search.Parse(q.text("q"))
+	if search.IsEmpty(query) {
+		q.invalid("q", "must contain a name of at least two letters, a date of birth, a phone number or an email address")
+	}
+	limit := q.limit(defaultSearchLimit, maxSearchLimit)
+	if !q.ok() {
+		return
+	}
+
+	candidates, err := s.store.Patients.Search(c.Request.Context(), query, searchCandidates)
+	if err != nil {
+		respondStoreError(c, err, "Patient")
+		return
+	}
+
+	matches := search.Rank(query, candidates)
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	c.JSON(http.StatusOK, matches)
+}
+
+func (s *server) getPatient(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, codeInvalidRequest, "Invalid ID format")
+		return
+	}
+
+	patient, err := s.store.Patients.Get(c.Request.Context(), id)
+	if err != nil {
+		respondStoreError(c, err, "Patient")
+		return
+	}
+
+	c.JSON(http.StatusOK, patient)
+}
+
+func (s *server) createPatient(c *gin.Context) {
+	var patientData patientRequest
+	if !bindJSON(c, &patientData) {
+		return
+	}
+
+	newPatient := patientData.toPatient()
+	if err := s.store.Patients.Create(c.Request.Context(), &newPatient); err != nil {
+		respondPatientError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newPatient)
+}
+
+func (s *server) updatePatient(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, codeInvalidRequest, "Invalid ID format")
+		return
+	}
+
+	var patientData patientRequest
+	if !bindJSON(c, &patientData) {
+		return
+	}
+
+	updatedPatient := patientData.toPatient()
+	updatedPatient.ID = id
+	if err := s.store.Patients.Update(c.Request.Context(), &updatedPatient); err != nil {
+		respondPatientError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedPatient)
+}
+
+func (s *server) deletePatient(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, codeInvalidRequest, "Invalid ID format")
+		return
+	}
+
+	if err := s.store.Patients.Delete(c.Request.Context(), id); err != nil {
+		respondStoreError(c, err, "Patient")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Patient deleted"})
+}
@@ -0,0 +1,344 @@
+// Package search turns the free text typed into the patient search box into
+// a store.PatientSearch and ranks the patients it finds.
+//
+// Names are matched by prefix, by Soundex code, which catches most phonetic
+// variants and many typos, and by edit distance for the typos Soundex misses
+// as long as the first letter is right. Stores keep the Soundex codes and the
+// digits of each phone number in indexed columns, computed with Soundex and
+// Digits, so that candidates can be found without scanning the table.
+package search
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+)
+
+// Scores for each kind of match. A patient's score is the sum over the
+// query: the email and phone number count once, each name term counts its
+// best match against the first or last name.
+const (
+	scoreEmail       = 100
+	scorePhone       = 100
+	scorePhonePrefix = 60
+	scoreDateOfBirth = 50
+	scoreName        = 40
+	scoreNamePrefix  = 30
+	scoreNameTypo    = 25
+	scoreNameSound   = 20
+)
+
+// minPhoneDigits is the fewest digits treated as part of a phone number
+const minPhoneDigits = 3
+
+// Layouts tried for a single date term. Day-month and month-day orders are
+// both tried, so 03/04/1980 matches patients born on either date.
+var dateLayouts = []string{
+	"2006-1-2", "2006/1/2", "2006.1.2", "20060102",
+	"1/2/2006", "2/1/2006", "1-2-2006", "2-1-2006", "2.1.2006",
+}
+
+// Layouts tried for a date written over three terms, after commas are
+// dropped
+var longDateLayouts = []string{
+	"Jan 2 2006", "January 2 2006", "2 Jan 2006", "2 January 2006",
+}
+
+// Parse splits a query into the parts of a patient search. Terms with an @
+// are taken as an email address, dates as a date of birth, runs of digits as
+// a phone number and anything else of two letters or more as a name.
+func Parse(query string) store.PatientSearch {
+	var s store.PatientSearch
+	terms := strings.FieldsFunc(query, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ','
+	})
+
+	for i := 0; i < len(terms); i++ {
+		if i+3 <= len(terms) {
+			if dates, ok := parseDates(strings.Join(terms[i:i+3], " "), longDateLayouts); ok {
+				s.DatesOfBirth = appendNew(s.DatesOfBirth, dates...)
+				i += 2
+				continue
+			}
+		}
+
+		term := terms[i]
+		if strings.Contains(term, "@") {
+			s.Email = strings.ToLower(term)
+			continue
+		}
+		dates, isDate := parseDates(term, dateLayouts)
+		s.DatesOfBirth = appendNew(s.DatesOfBirth, dates...)
+		if isPhone(term) && (!isDate || Digits(term) == term) {
+			// Numbers are often typed in groups, as in "555 123 4567"
+			s.Phone += Digits(term)
+			continue
+		}
+		if name := normalizeName(term); countLetters(name) >= 2 && !isDate {
+			s.Names = appendNew(s.Names, name)
+		}
+	}
+
+	if len(s.Phone) < minPhoneDigits {
+		s.Phone = ""
+	}
+	return s
+}
+
+// IsEmpty reports whether s has nothing to search for
+func IsEmpty(s store.PatientSearch) bool {
+	return len(s.Names) == 0 && len(s.DatesOfBirth) == 0 && s.Phone == "" && s.Email == ""
+}
+
+// Match is a patient found by a search and how well it matched
+type Match struct {
+	models.Patient
+	Score int `json:"score"`
+}
+
+// Rank scores patients against s and returns those that match anything,
+// best first. Equal scores are ordered by name and then ID.
+func Rank(s store.PatientSearch, patients []models.Patient) []Match {
+	matches := []Match{}
+	for _, p := range patients {
+		if score := Score(s, p); score > 0 {
+			matches = append(matches, Match{Patient: p, Score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if !strings.EqualFold(a.LastName, b.LastName) {
+			return strings.ToLower(a.LastName) < strings.ToLower(b.LastName)
+		}
+		if !strings.EqualFold(a.FirstName, b.FirstName) {
+			return strings.ToLower(a.FirstName) < strings.ToLower(b.FirstName)
+		}
+		return a.ID < b.ID
+	})
+	return matches
+}
+
+// Score rates how well p matches s; 0 means it does not match at all
+func Score(s store.PatientSearch, p models.Patient) int {
+	score := 0
+	if s.Email != "" && strings.EqualFold(s.Email, p.Email) {
+		score += scoreEmail
+	}
+	if s.Phone != "" {
+		phone := Digits(p.Phone)
+		if phone == s.Phone {
+			score += scorePhone
+		} else if strings.HasPrefix(phone, s.Phone) {
+			score += scorePhonePrefix
+		}
+	}
+	for _, date := range s.DatesOfBirth {
+		if p.DateOfBirth == date {
+			score += scoreDateOfBirth
+			break
+		}
+	}
+
+	firstName, lastName := normalizeName(p.FirstName), normalizeName(p.LastName)
+	for _, name := range s.Names {
+		best := scoreNameMatch(name, firstName)
+		if last := scoreNameMatch(name, lastName); last > best {
+			best = last
+		}
+		score += best
+	}
+	return score
+}
+
+// scoreNameMatch rates how well the query term matches one name
+func scoreNameMatch(term, name string) int {
+	switch {
+	case name == "":
+		return 0
+	case term == name:
+		return scoreName
+	case strings.HasPrefix(name, term):
+		return scoreNamePrefix
+	case distance(term, name) <= MaxTypos(term):
+		return scoreNameTypo
+	case Soundex(term) != "" && Soundex(term) == Soundex(name):
+		return scoreNameSound
+	}
+	return 0
+}
+
+// MaxTypos is how many edits a name term of this length may be away from a
+// name and still count as a typo of it
+func MaxTypos(term string) int {
+	switch n := len([]rune(term)); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	}
+	return 2
+}
+
+// distance is the number of insertions, deletions, substitutions and swaps
+// of adjacent letters that turn a into b
+func distance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	// d[i][j] is the distance between s[:i] and t[:j]
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(s)][len(t)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+// soundexCodes maps each consonant to its Soundex digit; vowels, H, W and Y
+// have none
+var soundexCodes = map[rune]byte{
+	'B': '1', 'F': '1', 'P': '1', 'V': '1',
+	'C': '2', 'G': '2', 'J': '2', 'K': '2', 'Q': '2', 'S': '2', 'X': '2', 'Z': '2',
+	'D': '3', 'T': '3',
+	'L': '4',
+	'M': '5', 'N': '5',
+	'R': '6',
+}
+
+// Soundex returns the American Soundex code of name, such as "R163" for
+// both "Robert" and "Rupert", or "" if it has no letters A to Z.
+func Soundex(name string) string {
+	code := make([]byte, 0, 4)
+	var last byte
+	for _, r := range strings.ToUpper(name) {
+		if r < 'A' || r > 'Z' {
+			continue
+		}
+		digit := soundexCodes[r]
+		if len(code) == 0 {
+			code = append(code, byte(r))
+			last = digit
+			continue
+		}
+		switch {
+		case digit != 0 && digit != last:
+			code = append(code, digit)
+			if len(code) == 4 {
+				return string(code)
+			}
+		case r == 'H' || r == 'W':
+			// Letters with the same code either side of H or W are coded once
+			continue
+		}
+		last = digit
+	}
+	if len(code) == 0 {
+		return ""
+	}
+	for len(code) < 4 {
+		code = append(code, '0')
+	}
+	return string(code)
+}
+
+// Digits returns the digits of a phone number without any punctuation
+func Digits(phone string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+}
+
+// isPhone reports whether term is made of digits and the punctuation found
+// in phone numbers
+func isPhone(term string) bool {
+	return Digits(term) != "" && strings.Trim(term, "0123456789+-().") == ""
+}
+
+// normalizeName lowercases a name and drops everything but letters,
+// apostrophes and hyphens
+func normalizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || r == '\'' || r == '-' {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
+
+func countLetters(s string) int {
+	n := 0
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			n++
+		}
+	}
+	return n
+}
+
+// parseDates returns term as a YYYY-MM-DD date for every layout it parses
+// with, leaving out dates in the future, and whether it parsed at all
+func parseDates(term string, layouts []string) ([]string, bool) {
+	var dates []string
+	parsed := false
+	for _, layout := range layouts {
+		date, err := time.Parse(layout, term)
+		if err != nil {
+			continue
+		}
+		parsed = true
+		if !date.After(time.Now()) {
+			dates = appendNew(dates, date.Format("2006-01-02"))
+		}
+	}
+	return dates, parsed
+}
+
+// appendNew appends the values not already in list
+func appendNew(list []string, values ...string) []string {
+	for _, v := range values {
+		found := false
+		for _, existing := range list {
+			if existing == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -0,0 +1,172 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+)
+
+func TestSoundex(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Robert", "R163"},
+		{"Rupert", "R163"},
+		{"Rubin", "R150"},
+		{"Ashcraft", "A261"},
+		{"Ashcroft", "A261"},
+		{"Tymczak", "T522"},
+		{"Pfister", "P236"},
+		{"Honeyman", "H555"},
+		{"Smith", "S530"},
+		{"Smyth", "S530"},
+		{"Lee", "L000"},
+		{"o'brien", "O165"},
+		{"", ""},
+		{"123", ""},
+	}
+	for _, tt := range tests {
+		if got := Soundex(tt.name); got != tt.want {
+			t.Errorf("Soundex(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"john", "john", 0},
+		{"jhon", "john", 1},
+		{"smtih", "smith", 1},
+		{"smyth", "smith", 1},
+		{"jon", "john", 1},
+		{"johnn", "john", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+		{"josé", "jose", 1},
+	}
+	for _, tt := range tests {
+		if got := distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("distance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := distance(tt.b, tt.a); got != tt.want {
+			t.Errorf("distance(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		query string
+		want  store.PatientSearch
+	}{
+		{"John Smith", store.PatientSearch{Names: []string{"john", "smith"}}},
+		{"  O'Brien-Jones ", store.PatientSearch{Names: []string{"o'brien-jones"}}},
+		{"john JOHN", store.PatientSearch{Names: []string{"john"}}},
+		{"1980-05-15", store.PatientSearch{DatesOfBirth: []string{"1980-05-15"}}},
+		{"1980/5/15", store.PatientSearch{DatesOfBirth: []string{"1980-05-15"}}},
+		{"05/15/1980", store.PatientSearch{DatesOfBirth: []string{"1980-05-15"}}},
+		{"15.05.1980", store.PatientSearch{DatesOfBirth: []string{"1980-05-15"}}},
+		{"03/04/1980", store.PatientSearch{DatesOfBirth: []string{"1980-03-04", "1980-04-03"}}},
+		{"May 15, 1980", store.PatientSearch{DatesOfBirth: []string{"1980-05-15"}}},
+		{"15 May 1980", store.PatientSearch{DatesOfBirth: []string{"1980-05-15"}}},
+		{"doe january 5 1980", store.PatientSearch{Names: []string{"doe"}, DatesOfBirth: []string{"1980-01-05"}}},
+		{"19800515", store.PatientSearch{DatesOfBirth: []string{"1980-05-15"}, Phone: "19800515"}},
+		{"2999-01-01", store.PatientSearch{}},
+		{"-- ''", store.PatientSearch{}},
+		{"555 123 4567", store.PatientSearch{Phone: "5551234567"}},
+		{"(555) 123-4567", store.PatientSearch{Phone: "5551234567"}},
+		{"+1 555.123.4567", store.PatientSearch{Phone: "15551234567"}},
+		{"12", store.PatientSearch{}},
+		{"John.Doe@Example.com", store.PatientSearch{Email: "john.doe@example.com"}},
+		{"doe 555-123", store.PatientSearch{Names: []string{"doe"}, Phone: "555123"}},
+		{"a", store.PatientSearch{}},
+		{"", store.PatientSearch{}},
+	}
+	for _, tt := range tests {
+		if got := Parse(tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestScoreNames(t *testing.T) {
+	patient := models.Patient{FirstName: "Katherine", LastName: "O'Brien"}
+	tests := []struct {
+		term string
+		want int
+	}{
+		{"katherine", scoreName},
+		{"o'brien", scoreName},
+		{"kath", scoreNamePrefix},
+		{"katherin", scoreNamePrefix},
+		{"kathrine", scoreNameTypo},
+		{"catherine", scoreNameTypo},
+		{"obrien", scoreNameTypo},
+		{"katrin", scoreNameSound},
+		{"smith", 0},
+	}
+	for _, tt := range tests {
+		s := store.PatientSearch{Names: []string{tt.term}}
+		if got := Score(s, patient); got != tt.want {
+			t.Errorf("Score(%q) = %d, want %d", tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestScoreFields(t *testing.T) {
+	patient := models.Patient{
+		FirstName:   "John",
+		LastName:    "Doe",
+		DateOfBirth: "1980-05-15",
+		Email:       "john.doe@example.com",
+		Phone:       "555-123-4567",
+	}
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"JOHN.DOE@example.com", scoreEmail},
+		{"other@example.com", 0},
+		{"555 123 4567", scorePhone},
+		{"555-123", scorePhonePrefix},
+		{"123-4567", 0},
+		{"05/15/1980", scoreDateOfBirth},
+		{"john doe 1980-05-15", 2*scoreName + scoreDateOfBirth},
+	}
+	for _, tt := range tests {
+		if got := Score(Parse(tt.query), patient); got != tt.want {
+			t.Errorf("Score(%q) = %d, want %d", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	patients := []models.Patient{
+		{ID: 1, FirstName: "Jane", LastName: "Smith"},
+		{ID: 2, FirstName: "John", LastName: "Smyth"},
+		{ID: 3, FirstName: "Bob", LastName: "Jones"},
+		{ID: 4, FirstName: "John", LastName: "Smith"},
+		{ID: 5, FirstName: "Jon", LastName: "Smith"},
+	}
+
+	matches := Rank(Parse("john smith"), patients)
+	var got []int
+	for _, m := range matches {
+		got = append(got, m.ID)
+	}
+	// John Smith matches both names exactly; Jon Smith and John Smyth have a
+	// typo in one; Jane Smith only sounds like "john"; Bob Jones matches nothing
+	want := []int{4, 5, 2, 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Rank() order = %v, want %v", got, want)
+	}
+	if matches[0].Score != 2*scoreName {
+		t.Errorf("Rank() best score = %d, want %d", matches[0].Score, 2*scoreName)
+	}
+}
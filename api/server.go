@@ -0,0 +1,145 @@
+package main
+
+import (
+	"carehub-microservice/config"
+	"carehub-microservice/monitoring"
+	"carehub-microservice/store"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// server holds the dependencies shared by the HTTP handlers
+type server struct {
+	store store.Store
+	// db is the connection behind store, checked by /readyz. It is nil for
+	// the memory driver.
+	db        *sql.DB
+	metrics   *monitoring.Metrics
+	jwtSecret []byte
+	settings  settingsCache
+}
+
+func newServer(st store.Store, database *sql.DB, authCfg config.AuthConfig) (*server, error) {
+	secret, err := loadJWTSecret(authCfg)
+	if err != nil {
+		return nil, err
+	}
+	return &server{
+		store:     st,
+		db:        database,
+		metrics:   monitoring.New(st.Analytics, database),
+		jwtSecret: secret,
+	}, nil
+}
+
+// untracedPaths are polled by the orchestrator and Prometheus and would only
+// add noise to traces
+var untracedPaths = map[string]bool{"/healthz": true, "/readyz": true, "/metrics": true}
+
+// router builds the gin engine with every route registered. It fails if a
+// route under /api has no authorization policy. serviceName is recorded on
+// the request spans.
+func (s *server) router(cfg config.ServerConfig, serviceName string) (*gin.Engine, error) {
+	if err := registerValidations(); err != nil {
+		return nil, err
+	}
+
+	r := gin.New()
+	// The request span comes first so the access log can carry its trace ID
+	r.Use(otelgin.Middleware(serviceName, otelgin.WithFilter(func(req *http.Request) bool {
+		return !untracedPaths[req.URL.Path]
+	})))
+	r.Use(requestLogger(), s.metrics.Middleware(), recoveryLogger())
+
+	// Configure CORS to allow frontend requests
+	r.Use(cors.New(cors.Config{
+		AllowOrigins:     cfg.CORSOrigins,
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", requestIDHeader, "traceparent", "tracestate"},
+		ExposeHeaders:    []string{"Content-Length", requestIDHeader, "X-Total-Count", "Link"},
+		AllowCredentials: true,
+		MaxAge:           12 * time.Hour,
+	}))
+	r.Use(queryTimeoutMiddleware(cfg.QueryTimeout))
+	r.NoRoute(noRoute)
+
+	// Health and metrics endpoints for the orchestrator and Prometheus, outside
+	// /api so they need no token
+	r.GET("/healthz", s.healthz)
+	r.GET("/readyz", s.readyz)
+	r.GET("/metrics", gin.WrapH(s.metrics.Handler()))
+
+	// Authentication endpoints
+	auth := r.Group("/auth")
+	{
+		auth.POST("/login", s.login)
+		auth.POST("/signup", s.signup)
+	}
+
+	// API routes
+	api := r.Group("/api", s.authMiddleware(), authorizeMiddleware())
+	{
+		// Patient endpoints
+		api.GET("/patients", s.getPatients)
+		api.GET("/patients/search", s.searchPatients)
+		api.GET("/patients/:id", s.getPatient)
+		api.POST("/patients", s.createPatient)
+		api.PUT("/patients/:id", s.updatePatient)
+		api.DELETE("/patients/:id", s.deletePatient)
+
+		// Appointment endpoints
+		api.GET("/appointments", s.getAppointments)
+		api.GET("/appointments/:id", s.getAppointment)
+		api.POST("/appointments", s.createAppointment)
+		api.PUT("/appointments/:id", s.updateAppointment)
+		api.DELETE("/appointments/:id", s.deleteAppointment)
+
+		// Health metric endpoints
+		api.GET("/patients/:id/metrics", s.getPatientHealthMetrics)
+		api.POST("/patients/:id/metrics", s.recordHealthMetric)
+
+		// Doctor endpoints
+		api.GET("/doctors", s.getDoctors)
+		api.GET("/doctors/:id", s.getDoctor)
+		api.PUT("/doctors/:id", s.updateDoctor)
+
+		// Blog endpoints
+		api.GET("/blogs", s.getBlogs)
+		api.GET("/blogs/:id", s.getBlog)
+		api.POST("/blogs", s.createBlog)
+		api.PUT("/blogs/:id", s.updateBlog)
+		api.DELETE("/blogs/:id", s.deleteBlog)
+
+		// Intern endpoints
+		api.GET("/interns", s.getInterns)
+		api.GET("/interns/:id", s.getIntern)
+
+		// Hospital endpoint (only GET)
+		api.GET("/hospital", s.getHospital)
+
+		// User management endpoints
+		api.GET("/users", s.getUsers)
+		api.GET("/users/:id", s.getUser)
+		api.POST("/users", s.createUser)
+		api.PUT("/users/:id", s.updateUser)
+		api.DELETE("/users/:id", s.deleteUser)
+
+		// System settings endpoints
+		api.GET("/settings", s.getSettings)
+		api.PUT("/settings", s.updateSettings)
+		api.GET("/settings/history", s.getSettingsHistory)
+
+		// Analytics endpoint
+		api.GET("/analytics", s.getAnalytics)
+	}
+
+	if err := checkPolicyCoverage(r.Routes()); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"carehub-microservice/store"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/exp/slog"
+)
+
+type SystemSettings struct {
+	HospitalName             string `json:"hospitalName"`
+	ContactEmail             string `json:"contactEmail"`
+	ContactPhone             string `json:"contactPhone"`
+	Address                  string `json:"address"`
+	MaintenanceMode          bool   `json:"maintenanceMode"`
+	AppointmentNotifications bool   `json:"appointmentNotifications"`
+	DefaultLanguage          string `json:"defaultLanguage"`
+	WelcomeMessage           string `json:"welcomeMessage"`
+	PrivacyPolicy            string `json:"privacyPolicy"`
+	TermsOfService           string `json:"termsOfService"`
+
+	// Appointments must start on a multiple of this many minutes
+	AppointmentSlotMinutes int `json:"appointmentSlotMinutes"`
+	// Minimum length for new passwords
+	PasswordMinLength int `json:"passwordMinLength"`
+	// Lifetime of access tokens issued at login
+	SessionTimeoutMinutes int `json:"sessionTimeoutMinutes"`
+}
+
+type SettingsVersion struct {
+	Version   int            `json:"version"`
+	Settings  SystemSettings `json:"settings"`
+	UpdatedBy *int           `json:"updatedBy"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+var defaultSettings = SystemSettings{
+	HospitalName:             "CareHub Hospital",
+	ContactEmail:             "info@carehub.com",
+	ContactPhone:             "555-111-2222",
+	Address:                  "789 Health Ave, Metropolis, USA",
+	MaintenanceMode:          false,
+	AppointmentNotifications: true,
+	DefaultLanguage:          "en",
+	AppointmentSlotMinutes:   15,
+	PasswordMinLength:        8,
+	SessionTimeoutMinutes:    12 * 60,
+}
+
+var supportedLanguages = []string{"en", "es", "fr", "de", "hi"}
+
+// validate lists the problems with the settings, empty when they are valid
+func (s SystemSettings) validate() []fieldError {
+	var errs []fieldError
+
+	if len(s.HospitalName) < 2 {
+		errs = append(errs, fieldError{Field: "hospitalName", Message: "must be at least 2 characters"})
+	}
+	if _, err := mail.ParseAddress(s.ContactEmail); err != nil {
+		errs = append(errs, fieldError{Field: "contactEmail", Message: "must be a valid email address"})
+	}
+	if len(s.ContactPhone) < 5 {
+		errs = append(errs, fieldError{Field: "contactPhone", Message: "must be at least 5 characters"})
+	}
+	if len(s.Address) < 5 {
+		errs = append(errs, fieldError{Field: "address", Message: "must be at least 5 characters"})
+	}
+	if !contains(supportedLanguages, s.DefaultLanguage) {
+		errs = append(errs, fieldError{Field: "defaultLanguage", Message: "is not a supported language"})
+	}
+	if s.AppointmentSlotMinutes < 5 || s.AppointmentSlotMinutes > 240 ||
+		(60%s.AppointmentSlotMinutes != 0 && s.AppointmentSlotMinutes%60 != 0) {
+		errs = append(errs, fieldError{Field: "appointmentSlotMinutes", Message: "must be between 5 and 240 and divide evenly into hours"})
+	}
+	if s.PasswordMinLength < 6 || s.PasswordMinLength > 72 {
+		errs = append(errs, fieldError{Field: "passwordMinLength", Message: "must be between 6 and 72"})
+	}
+	if s.SessionTimeoutMinutes < 5 || s.SessionTimeoutMinutes > 7*24*60 {
+		errs = append(errs, fieldError{Field: "sessionTimeoutMinutes", Message: "must be between 5 minutes and 7 days"})
+	}
+
+	return errs
+}
+
+// settingsCache keeps the effective settings in memory so that handlers
+// consulting them do not hit the database on every request.
+type settingsCache struct {
+	sync.Mutex
+	settings SystemSettings
+	loadedAt time.Time
+	// loading is closed when the refresh in progress finishes, nil if none is
+	loading chan struct{}
+}
+
+const (
+	settingsCacheTTL = 30 * time.Second
+	// settingsLoadTimeout bounds a refresh, which runs apart from the
+	// request that started it
+	settingsLoadTimeout = 5 * time.Second
+)
+
+// decodeSettings overlays a stored settings document on the defaults
+func decodeSettings(data []byte) (SystemSettings, error) {
+	settings := defaultSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return defaultSettings, err
+	}
+	return settings, nil
+}
+
+// loadSettings returns the defaults overlaid with the latest stored version
+func (s *server) loadSettings(ctx context.Context) (SystemSettings, int, error) {
+	record, err := s.store.Settings.Latest(ctx)
+	if errors.Is(err, store.ErrNotFound) {
+		return defaultSettings, 0, nil
+	}
+	if err != nil {
+		return defaultSettings, 0, err
+	}
+
+	settings, err := decodeSettings(record.Data)
+	if err != nil {
+		return settings, 0, err
+	}
+	return settings, record.Version, nil
+}
+
+// currentSettings returns the effective settings, falling back to the last
+// ones loaded, or the defaults, if they cannot be loaded. Once the cache has
+// expired every caller waits for the same refresh, but only for as long as
+// its own ctx allows.
+func (s *server) currentSettings(ctx context.Context) SystemSettings {
+	s.settings.Lock()
+	if !s.settings.loadedAt.IsZero() && time.Since(s.settings.loadedAt) < settingsCacheTTL {
+		defer s.settings.Unlock()
+		return s.settings.settings
+	}
+	loading := s.settings.loading
+	if loading == nil {
+		loading = make(chan struct{})
+		s.settings.loading = loading
+		go s.refreshSettings(loading)
+	}
+	s.settings.Unlock()
+
+	select {
+	case <-loading:
+	case <-ctx.Done():
+	}
+
+	s.settings.Lock()
+	defer s.settings.Unlock()
+	if s.settings.loadedAt.IsZero() {
+		return defaultSettings
+	}
+	return s.settings.settings
+}
+
+// refreshSettings reloads the cache and closes done. It does not use the
+// context of the request that needed the settings, so that one caller
+// giving up does not fail the refresh for everyone waiting on it.
+func (s *server) refreshSettings(done chan struct{}) {
+	ctx, cancel := context.WithTimeout(context.Background(), settingsLoadTimeout)
+	defer cancel()
+	settings, _, err := s.loadSettings(ctx)
+
+	s.settings.Lock()
+	if err != nil {
+		slog.Warn("Failed to load system settings", "error", err)
+	} else {
+		s.settings.settings = settings
+		s.settings.loadedAt = time.Now()
+	}
+	s.settings.loading = nil
+	s.settings.Unlock()
+	close(done)
+}
+
+func (s *server) cacheSettings(settings SystemSettings) {
+	s.settings.Lock()
+	s.settings.settings = settings
+	s.settings.loadedAt = time.Now()
+	s.settings.Unlock()
+}
+
+// checkPasswordPolicy returns a message describing why password is rejected, or ""
+func (s *server) checkPasswordPolicy(ctx context.Context, password string) string {
+	minLength := s.currentSettings(ctx).PasswordMinLength
+	if len(password) < minLength {
+		return "Password must be at least " + strconv.Itoa(minLength) + " characters"
+	}
+	return ""
+}
+
+// checkAppointmentSlot returns a message if t does not start on a slot boundary, or ""
+func (s *server) checkAppointmentSlot(ctx context.Context, t time.Time) string {
+	slot := s.currentSettings(ctx).AppointmentSlotMinutes
+	if t.Second() != 0 || t.Nanosecond() != 0 || (t.Hour()*60+t.Minute())%slot != 0 {
+		return "Appointments must start on a " + strconv.Itoa(slot) + " minute boundary"
+	}
+	return ""
+}
+
+// --- Settings Handlers ---
+func (s *server) getSettings(c *gin.Context) {
+	settings, version, err := s.loadSettings(c.Request.Context())
+	if err != nil {
+		respondStoreError(c, err, "Settings")
+		return
+	}
+	s.cacheSettings(settings)
+
+	c.Header("X-Settings-Version", strconv.Itoa(version))
+	c.JSON(http.StatusOK, settings)
+}
+
+func (s *server) updateSettings(c *gin.Context) {
+	settings, _, err := s.loadSettings(c.Request.Context())
+	if err != nil {
+		respondStoreError(c, err, "Settings")
+		return
+	}
+
+	// Fields omitted from the body keep their current values
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&settings); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if errs := settings.validate(); len(errs) > 0 {
+		respondInvalid(c, "Invalid settings", errs...)
+		return
+	}
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		respondInternalError(c, err)
+		return
+	}
+
+	version, err := s.store.Settings.Save(c.Request.Context(), data, c.GetInt(ctxUserID))
+	if err != nil {
+		respondStoreError(c, err, "Settings")
+		return
+	}
+
+	s.cacheSettings(settings)
+	c.Header("X-Settings-Version", strconv.Itoa(version))
+	c.JSON(http.StatusOK, settings)
+}
+
+func (s *server) getSettingsHistory(c *gin.Context) {
+	records, err := s.store.Settings.History(c.Request.Context())
+	if err != nil {
+		respondStoreError(c, err, "Settings")
+		return
+	}
+
+	history := []SettingsVersion{}
+	for _, record := range records {
+		settings, err := decodeSettings(record.Data)
+		if err != nil {
+			respondInternalError(c, err)
+			return
+		}
+		history = append(history, SettingsVersion{
+			Version:   record.Version,
+			Settings:  settings,
+			UpdatedBy: record.UpdatedBy,
+			CreatedAt: record.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, history)
+}
@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+)
+
+type analyticsStore struct {
+	*db
+}
+
+// period formats t the same way the SQL store's DATE_FORMAT patterns do
+func period(t time.Time, groupBy string) (string, error) {
+	switch groupBy {
+	case "day":
+		return t.Format("2006-01-02"), nil
+	case "week":
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week), nil
+	case "month":
+		return t.Format("2006-01"), nil
+	case "year":
+		return t.Format("2006"), nil
+	}
+	return "", fmt.Errorf("unsupported grouping %q", groupBy)
+}
+
+// counter tallies labels and returns them most frequent first
+type counter map[string]int
+
+func (c counter) sorted() []models.LabelCount {
+	results := make([]models.LabelCount, 0, len(c))
+	for label, count := range c {
+		results = append(results, models.LabelCount{Label: label, Count: count})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Count != results[j].Count {
+			return results[i].Count > results[j].Count
+		}
+		return results[i].Label < results[j].Label
+	})
+	return results
+}
+
+func (s *analyticsStore) Analytics(_ context.Context, q store.AnalyticsQuery) (models.Analytics, error) {
+	var analytics models.Analytics
+	if _, err := period(q.From, q.GroupBy); err != nil {
+		return analytics, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	inRange := func(t time.Time) bool {
+		return !t.Before(q.From) && t.Before(q.To)
+	}
+	now := time.Now()
+
+	// Summary figures are global rather than limited to the requested range
+	summary := &analytics.Summary
+	summary.TotalPatients = len(s.patients)
+	summary.ActiveDoctors = len(s.doctors)
+	summary.TotalUsers = len(s.users)
+	summary.TotalBlogs = len(s.blogs)
+
+	newPatients := counter{}
+	for _, p := range s.patients {
+		if !p.CreatedAt.Before(q.RecentSince) {
+			summary.RecentPatients++
+		}
+		if inRange(p.CreatedAt) {
+			label, _ := period(p.CreatedAt, q.GroupBy)
+			newPatients[label]++
+		}
+	}
+
+	byStatus, byDoctor := counter{}, counter{}
+	for _, a := range s.appointments {
+		if a.Status == "Scheduled" && !a.DateTime.Before(now) {
+			summary.ActiveAppointments++
+		}
+		if a.Status == "Completed" {
+			summary.CompletedAppointments++
+		}
+		if inRange(a.DateTime) {
+			byStatus[a.Status]++
+			byDoctor[a.Doctor]++
+		}
+	}
+
+	byType := counter{}
+	for _, m := range s.metrics {
+		if inRange(m.RecordedAt) {
+			byType[m.Type]++
+		}
+	}
+
+	byAuthor := counter{}
+	for _, b := range s.blogs {
+		if published, err := time.Parse(time.RFC3339, b.PublishedAt); err == nil && inRange(published) {
+			byAuthor[b.AuthorName]++
+		}
+	}
+
+	byRole := counter{}
+	for _, u := range s.users {
+		byRole[u.Role]++
+	}
+
+	// New patients are ordered by period rather than by count
+	analytics.NewPatients = []models.PeriodCount{}
+	for label, count := range newPatients {
+		analytics.NewPatients = append(analytics.NewPatients, models.PeriodCount{Period: label, Count: count})
+	}
+	sort.Slice(analytics.NewPatients, func(i, j int) bool {
+		return analytics.NewPatients[i].Period < analytics.NewPatients[j].Period
+	})
+
+	analytics.AppointmentsByStatus = byStatus.sorted()
+	analytics.AppointmentsByDoctor = byDoctor.sorted()
+	analytics.MetricsByType = byType.sorted()
+	analytics.BlogsByAuthor = byAuthor.sorted()
+	analytics.UsersByRole = byRole.sorted()
+	return analytics, nil
+}
+
+func (s *analyticsStore) Gauges(_ context.Context, dayStart, dayEnd time.Time) (models.Gauges, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	gauges := models.Gauges{Patients: len(s.patients), Users: len(s.users)}
+	for _, a := range s.appointments {
+		if a.Status == "Scheduled" && !a.DateTime.Before(dayStart) && a.DateTime.Before(dayEnd) {
+			gauges.AppointmentsScheduledToday++
+		}
+	}
+	return gauges, nil
+}
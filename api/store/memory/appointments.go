@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+	"context"
+)
+
+type appointmentStore struct {
+	*db
+}
+
+var appointmentSortFields = map[string]compareFunc[models.Appointment]{
+	"patientId": func(a, b models.Appointment) int { return compareInt(a.PatientID, b.PatientID) },
+	"dateTime":  func(a, b models.Appointment) int { return compareTime(a.DateTime, b.DateTime) },
+	"status":    func(a, b models.Appointment) int { return compareText(a.Status, b.Status) },
+	"doctor":    func(a, b models.Appointment) int { return compareText(a.Doctor, b.Doctor) },
+}
+
+func (s *appointmentStore) List(_ context.Context, f store.AppointmentFilter, p store.Page) ([]models.Appointment, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	appointments := []models.Appointment{}
+	for _, a := range sortedByID(s.appointments) {
+		if f.PatientID != 0 && a.PatientID != f.PatientID ||
+			!matches(f.Status, a.Status) || !matches(f.Doctor, a.Doctor) ||
+			!f.From.IsZero() && a.DateTime.Before(f.From) ||
+			!f.To.IsZero() && !a.DateTime.Before(f.To) {
+			continue
+		}
+		appointments = append(appointments, a)
+	}
+	appointments, total := page(appointments, p, appointmentSortFields)
+	return appointments, total, nil
+}
+
+func (s *appointmentStore) Get(_ context.Context, id int) (models.Appointment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	a, ok := s.appointments[id]
+	if !ok {
+		return a, store.ErrNotFound
+	}
+	return a, nil
+}
+
+func (s *appointmentStore) Create(_ context.Context, a *models.Appointment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkPatient(a.PatientID); err != nil {
+		return err
+	}
+	a.ID = s.nextID("appointments")
+	s.appointments[a.ID] = *a
+	return nil
+}
+
+func (s *appointmentStore) Update(_ context.Context, a *models.Appointment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.appointments[a.ID]; !ok {
+		return store.ErrNotFound
+	}
+	if err := s.checkPatient(a.PatientID); err != nil {
+		return err
+	}
+	s.appointments[a.ID] = *a
+	return nil
+}
+
+func (s *appointmentStore) Delete(_ context.Context, id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.appointments[id]; !ok {
+		return store.ErrNotFound
+	}
+	delete(s.appointments, id)
+	return nil
+}
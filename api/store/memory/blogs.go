@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+)
+
+type blogStore struct {
+	*db
+}
+
+func copyBlog(b models.Blog) models.Blog {
+	b.Tags = copyStrings(b.Tags)
+	return b
+}
+
+var blogSortFields = map[string]compareFunc[models.Blog]{
+	"title":       func(a, b models.Blog) int { return compareText(a.Title, b.Title) },
+	"authorName":  func(a, b models.Blog) int { return compareText(a.AuthorName, b.AuthorName) },
+	"publishedAt": func(a, b models.Blog) int { return compareText(a.PublishedAt, b.PublishedAt) },
+}
+
+func (s *blogStore) List(_ context.Context, f store.BlogFilter, p store.Page) ([]models.Blog, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	blogs := []models.Blog{}
+	for _, b := range sortedByID(s.blogs) {
+		if f.AuthorID == 0 || b.AuthorId == f.AuthorID {
+			blogs = append(blogs, copyBlog(b))
+		}
+	}
+	blogs, total := page(blogs, p, blogSortFields)
+	return blogs, total, nil
+}
+
+func (s *blogStore) Get(_ context.Context, id int) (models.Blog, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	b, ok := s.blogs[id]
+	if !ok {
+		return b, store.ErrNotFound
+	}
+	return copyBlog(b), nil
+}
+
+// checkAuthor enforces the foreign key from blogs to users
+func (s *blogStore) checkAuthor(id int) error {
+	if _, ok := s.users[id]; !ok {
+		return fmt.Errorf("%w: user %d does not exist", store.ErrInvalidReference, id)
+	}
+	return nil
+}
+
+func (s *blogStore) Create(_ context.Context, b *models.Blog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkAuthor(b.AuthorId); err != nil {
+		return err
+	}
+	b.ID = s.nextID("blogs")
+	s.blogs[b.ID] = copyBlog(*b)
+	return nil
+}
+
+func (s *blogStore) Update(_ context.Context, b *models.Blog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.blogs[b.ID]
+	if !ok {
+		return store.ErrNotFound
+	}
+	if err := s.checkAuthor(b.AuthorId); err != nil {
+		return err
+	}
+	b.PublishedAt = current.PublishedAt
+	s.blogs[b.ID] = copyBlog(*b)
+	return nil
+}
+
+func (s *blogStore) Delete(_ context.Context, id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.blogs[id]; !ok {
+		return store.ErrNotFound
+	}
+	delete(s.blogs, id)
+	return nil
+}
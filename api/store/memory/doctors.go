@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+	"context"
+)
+
+type doctorStore struct {
+	*db
+}
+
+func copyDoctor(d models.Doctor) models.Doctor {
+	d.Education = copyStrings(d.Education)
+	d.Experience = copyStrings(d.Experience)
+	if d.Education == nil {
+		d.Education = []string{}
+	}
+	if d.Experience == nil {
+		d.Experience = []string{}
+	}
+	return d
+}
+
+var doctorSortFields = map[string]compareFunc[models.Doctor]{
+	"name":           func(a, b models.Doctor) int { return compareText(a.Name, b.Name) },
+	"department":     func(a, b models.Doctor) int { return compareText(a.Department, b.Department) },
+	"specialization": func(a, b models.Doctor) int { return compareText(a.Specialization, b.Specialization) },
+}
+
+func (s *doctorStore) List(_ context.Context, f store.DoctorFilter, p store.Page) ([]models.Doctor, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	doctors := []models.Doctor{}
+	for _, d := range sortedByID(s.doctors) {
+		if matches(f.Department, d.Department) && matches(f.Specialization, d.Specialization) {
+			doctors = append(doctors, copyDoctor(d))
+		}
+	}
+	doctors, total := page(doctors, p, doctorSortFields)
+	return doctors, total, nil
+}
+
+func (s *doctorStore) Get(_ context.Context, id int) (models.Doctor, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	d, ok := s.doctors[id]
+	if !ok {
+		return d, store.ErrNotFound
+	}
+	return copyDoctor(d), nil
+}
+
+func (s *doctorStore) Update(_ context.Context, d *models.Doctor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.doctors[d.ID]; !ok {
+		return store.ErrNotFound
+	}
+	for _, other := range s.doctors {
+		if other.ID != d.ID && other.Email == d.Email {
+			return duplicate("doctor", "email", d.Email)
+		}
+	}
+	s.doctors[d.ID] = copyDoctor(*d)
+	return nil
+}
@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+	"context"
+)
+
+type internStore struct {
+	*db
+}
+
+var internSortFields = map[string]compareFunc[models.Intern]{
+	"name":       func(a, b models.Intern) int { return compareText(a.Name, b.Name) },
+	"department": func(a, b models.Intern) int { return compareText(a.Department, b.Department) },
+}
+
+func (s *internStore) List(_ context.Context, f store.InternFilter, p store.Page) ([]models.Intern, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	interns := []models.Intern{}
+	for _, i := range sortedByID(s.interns) {
+		if matches(f.Department, i.Department) {
+			interns = append(interns, i)
+		}
+	}
+	interns, total := page(interns, p, internSortFields)
+	return interns, total, nil
+}
+
+func (s *internStore) Get(_ context.Context, id int) (models.Intern, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i, ok := s.interns[id]
+	if !ok {
+		return i, store.ErrNotFound
+	}
+	return i, nil
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"carehub-microservice/store"
+)
+
+// compareFunc orders two records by one field, returning a negative number,
+// zero or a positive number as the SQL store's ORDER BY would
+type compareFunc[T any] func(a, b T) int
+
+// page orders rows, which must already be sorted by ID, by the field p.Sort
+// names in sortFields and returns the requested slice along with the number
+// of rows. Sorting is stable, so ties stay in ID order like the SQL store's
+// tie-break.
+func page[T any](rows []T, p store.Page, sortFields map[string]compareFunc[T]) ([]T, int) {
+	if compare, ok := sortFields[p.Sort]; ok {
+		sort.SliceStable(rows, func(i, j int) bool {
+			return compare(rows[i], rows[j]) < 0
+		})
+	}
+	if p.Desc {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	total := len(rows)
+	start := p.Offset
+	if start > total {
+		start = total
+	}
+	end := total
+	if p.Limit > 0 && start+p.Limit < total {
+		end = start + p.Limit
+	}
+	return rows[start:end], total
+}
+
+// matches reports whether value passes a text filter, which like the SQL
+// store's ignores case and matches everything when empty
+func matches(filter, value string) bool {
+	return filter == "" || strings.EqualFold(filter, value)
+}
+
+func compareText(a, b string) int {
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareTime(a, b time.Time) int {
+	return a.Compare(b)
+}
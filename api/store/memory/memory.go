@@ -0,0 +1,138 @@
+// Package memory implements the store interfaces in process memory. Nothing
+// is persisted; it is meant for local development and tests, where it removes
+// the need for a MySQL server. No operation waits on I/O, so the contexts
+// passed to its methods are ignored.
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+)
+
+// Seed is the initial content of a new in-memory store. IDs in the seed are
+// kept, and new records are numbered after the highest one.
+type Seed struct {
+	Patients     []models.Patient
+	Appointments []models.Appointment
+	Metrics      []models.HealthMetric
+	Doctors      []models.Doctor
+	Blogs        []models.Blog
+	Interns      []models.Intern
+	Users        []models.User
+}
+
+// db holds every table behind a single lock so that cascading deletes and
+// analytics see a consistent snapshot.
+type db struct {
+	mu sync.RWMutex
+
+	patients     map[int]models.Patient
+	appointments map[int]models.Appointment
+	metrics      map[int]models.HealthMetric
+	doctors      map[int]models.Doctor
+	blogs        map[int]models.Blog
+	interns      map[int]models.Intern
+	users        map[int]models.User
+	settings     []models.SettingsRecord
+
+	// lastID holds the highest ID handed out per table, like AUTO_INCREMENT
+	lastID map[string]int
+}
+
+// New returns a store.Store holding a copy of seed
+func New(seed Seed) store.Store {
+	d := &db{
+		patients:     map[int]models.Patient{},
+		appointments: map[int]models.Appointment{},
+		metrics:      map[int]models.HealthMetric{},
+		doctors:      map[int]models.Doctor{},
+		blogs:        map[int]models.Blog{},
+		interns:      map[int]models.Intern{},
+		users:        map[int]models.User{},
+		lastID:       map[string]int{},
+	}
+
+	for _, p := range seed.Patients {
+		d.patients[p.ID] = p
+		d.seen("patients", p.ID)
+	}
+	for _, a := range seed.Appointments {
+		d.appointments[a.ID] = a
+		d.seen("appointments", a.ID)
+	}
+	for _, m := range seed.Metrics {
+		d.metrics[m.ID] = m
+		d.seen("metrics", m.ID)
+	}
+	for _, doc := range seed.Doctors {
+		d.doctors[doc.ID] = copyDoctor(doc)
+		d.seen("doctors", doc.ID)
+	}
+	for _, b := range seed.Blogs {
+		d.blogs[b.ID] = copyBlog(b)
+		d.seen("blogs", b.ID)
+	}
+	for _, i := range seed.Interns {
+		d.interns[i.ID] = i
+		d.seen("interns", i.ID)
+	}
+	for _, u := range seed.Users {
+		d.users[u.ID] = u
+		d.seen("users", u.ID)
+	}
+
+	return store.Store{
+		Patients:     &patientStore{d},
+		Appointments: &appointmentStore{d},
+		Metrics:      &metricStore{d},
+		Doctors:      &doctorStore{d},
+		Blogs:        &blogStore{d},
+		Interns:      &internStore{d},
+		Users:        &userStore{d},
+		Settings:     &settingsStore{d},
+		Analytics:    &analyticsStore{d},
+	}
+}
+
+func (d *db) seen(table string, id int) {
+	if id > d.lastID[table] {
+		d.lastID[table] = id
+	}
+}
+
+func (d *db) nextID(table string) int {
+	d.lastID[table]++
+	return d.lastID[table]
+}
+
+// sortedByID returns the values of rows ordered by ID, as the SQL store does
+// for an unordered SELECT on an auto-increment primary key.
+func sortedByID[T any](rows map[int]T) []T {
+	ids := make([]int, 0, len(rows))
+	for id := range rows {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	values := make([]T, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, rows[id])
+	}
+	return values
+}
+
+// duplicate mirrors the error a unique index would raise
+func duplicate(table, column, value string) error {
+	return fmt.Errorf("%w: duplicate %s %s %q", store.ErrConflict, table, column, value)
+}
+
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string{}, s...)
+}
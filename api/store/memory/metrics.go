@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"carehub-microservice/models"
+)
+
+type metricStore struct {
+	*db
+}
+
+func (s *metricStore) ListByPatient(_ context.Context, patientID int) ([]models.HealthMetric, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var metrics []models.HealthMetric
+	for _, m := range sortedByID(s.metrics) {
+		if m.PatientID == patientID {
+			metrics = append(metrics, m)
+		}
+	}
+	return metrics, nil
+}
+
+func (s *metricStore) Create(_ context.Context, m *models.HealthMetric) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkPatient(m.PatientID); err != nil {
+		return err
+	}
+	m.ID = s.nextID("metrics")
+	m.RecordedAt = time.Now()
+	s.metrics[m.ID] = *m
+	return nil
+}
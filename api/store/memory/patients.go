@@ -0,0 +1,195 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"carehub-microservice/models"
+	"carehub-microservice/search"
+	"carehub-microservice/store"
+)
+
+type patientStore struct {
+	*db
+}
+
+var patientSortFields = map[string]compareFunc[models.Patient]{
+	"firstName":   func(a, b models.Patient) int { return compareText(a.FirstName, b.FirstName) },
+	"lastName":    func(a, b models.Patient) int { return compareText(a.LastName, b.LastName) },
+	"dateOfBirth": func(a, b models.Patient) int { return compareText(a.DateOfBirth, b.DateOfBirth) },
+	"createdAt":   func(a, b models.Patient) int { return compareTime(a.CreatedAt, b.CreatedAt) },
+}
+
+func (s *patientStore) List(_ context.Context, f store.PatientFilter, p store.Page) ([]models.Patient, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	patients := []models.Patient{}
+	for _, patient := range sortedByID(s.patients) {
+		if matches(f.FirstName, patient.FirstName) && matches(f.LastName, patient.LastName) && matches(f.Email, patient.Email) {
+			patients = append(patients, patient)
+		}
+	}
+	patients, total := page(patients, p, patientSortFields)
+	return patients, total, nil
+}
+
+func (s *patientStore) Search(_ context.Context, q store.PatientSearch, limit int) ([]models.Patient, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	patients := []models.Patient{}
+	tiers := map[int]int{}
+	for _, p := range sortedByID(s.patients) {
+		if tier := matchTier(q, p); tier >= 0 {
+			patients = append(patients, p)
+			tiers[p.ID] = tier
+		}
+	}
+	sort.SliceStable(patients, func(i, j int) bool {
+		return tiers[patients[i].ID] < tiers[patients[j].ID]
+	})
+	if limit > 0 && len(patients) > limit {
+		patients = patients[:limit]
+	}
+	return patients, nil
+}
+
+// Match tiers, strongest first, in the order the SQL store fetches them
+const (
+	matchExact = iota
+	matchPrefix
+	matchSound
+	matchTypo
+)
+
+// matchTier applies the rules of store.PatientSearch, computing the keys
+// the SQL store reads from its index columns. It returns the strongest tier
+// p matches in, or -1 if p is not a candidate.
+func matchTier(q store.PatientSearch, p models.Patient) int {
+	if q.Email != "" && strings.EqualFold(q.Email, p.Email) {
+		return matchExact
+	}
+	for _, date := range q.DatesOfBirth {
+		if p.DateOfBirth == date {
+			return matchExact
+		}
+	}
+	if q.Phone != "" && strings.HasPrefix(search.Digits(p.Phone), q.Phone) {
+		return matchExact
+	}
+	tier := -1
+	better := func(t int) {
+		if tier < 0 || t < tier {
+			tier = t
+		}
+	}
+	for _, name := range q.Names {
+		key := search.Soundex(name)
+		length, typos := utf8.RuneCountInString(name), search.MaxTypos(name)
+		first := string([]rune(name)[:1])
+		for _, n := range []string{p.FirstName, p.LastName} {
+			switch lower, diff := strings.ToLower(n), utf8.RuneCountInString(n)-length; {
+			case lower == name:
+				better(matchExact)
+			case strings.HasPrefix(lower, name):
+				better(matchPrefix)
+			case key != "" && search.Soundex(n) == key:
+				better(matchSound)
+			case typos > 0 && strings.HasPrefix(lower, first) && diff >= -typos && diff <= typos:
+				better(matchTypo)
+			}
+		}
+	}
+	return tier
+}
+
+// Reindex has nothing to do, the keys are computed as patients are searched
+func (s *patientStore) Reindex(context.Context) (int, error) {
+	return 0, nil
+}
+
+func (s *patientStore) Get(_ context.Context, id int) (models.Patient, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.patients[id]
+	if !ok {
+		return p, store.ErrNotFound
+	}
+	return p, nil
+}
+
+// checkEmail enforces the unique index on patients.email
+func (s *patientStore) checkEmail(email string, excludeID int) error {
+	for _, p := range s.patients {
+		if p.ID != excludeID && p.Email == email {
+			return duplicate("patient", "email", email)
+		}
+	}
+	return nil
+}
+
+func (s *patientStore) Create(_ context.Context, p *models.Patient) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkEmail(p.Email, 0); err != nil {
+		return err
+	}
+	p.ID = s.nextID("patients")
+	p.CreatedAt = time.Now()
+	s.patients[p.ID] = *p
+	return nil
+}
+
+func (s *patientStore) Update(_ context.Context, p *models.Patient) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.patients[p.ID]
+	if !ok {
+		return store.ErrNotFound
+	}
+	if err := s.checkEmail(p.Email, p.ID); err != nil {
+		return err
+	}
+	p.CreatedAt = current.CreatedAt
+	s.patients[p.ID] = *p
+	return nil
+}
+
+func (s *patientStore) Delete(_ context.Context, id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.patients[id]; !ok {
+		return store.ErrNotFound
+	}
+	delete(s.patients, id)
+
+	// Appointments and metrics reference patients with ON DELETE CASCADE
+	for aid, a := range s.appointments {
+		if a.PatientID == id {
+			delete(s.appointments, aid)
+		}
+	}
+	for mid, m := range s.metrics {
+		if m.PatientID == id {
+			delete(s.metrics, mid)
+		}
+	}
+	return nil
+}
+
+// checkPatient enforces the foreign keys that reference patients
+func (d *db) checkPatient(id int) error {
+	if _, ok := d.patients[id]; !ok {
+		return fmt.Errorf("%w: patient %d does not exist", store.ErrInvalidReference, id)
+	}
+	return nil
+}
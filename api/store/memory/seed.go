@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"time"
+
+	"carehub-microservice/models"
+)
+
+// DemoData returns the sample records the service shipped with as mock data.
+// Timestamps are relative to now so that appointments stay upcoming. User
+// passwords are plaintext and are upgraded to bcrypt hashes on first login,
+// like the legacy rows in the SQL seed migration.
+func DemoData() Seed {
+	return Seed{
+		Patients: []models.Patient{
+			{ID: 1, FirstName: "John", LastName: "Doe", DateOfBirth: "1980-05-15", Email: "john.doe@example.com", Phone: "555-123-4567", Address: "123 Main St, Anytown, CA", CreatedAt: time.Now().Add(-24 * time.Hour)},
+			{ID: 2, FirstName: "Jane", LastName: "Smith", DateOfBirth: "1975-08-21", Email: "jane.smith@example.com", Phone: "555-987-6543", Address: "456 Oak Ave, Somewhere, NY", CreatedAt: time.Now().Add(-48 * time.Hour)},
+		},
+		Appointments: []models.Appointment{
+			{ID: 1, PatientID: 1, DateTime: time.Now().Add(48 * time.Hour), Description: "Annual checkup", Status: "Scheduled", Doctor: "Dr. Brown"},
+			{ID: 2, PatientID: 2, DateTime: time.Now().Add(72 * time.Hour), Description: "Follow-up", Status: "Scheduled", Doctor: "Dr. Johnson"},
+		},
+		Metrics: []models.HealthMetric{
+			{ID: 1, PatientID: 1, Type: "Blood Pressure", Value: 120.80, Unit: "mmHg", RecordedAt: time.Now().Add(-24 * time.Hour)},
+			{ID: 2, PatientID: 1, Type: "Heart Rate", Value: 72, Unit: "bpm", RecordedAt: time.Now().Add(-24 * time.Hour)},
+			{ID: 3, PatientID: 2, Type: "Blood Pressure", Value: 118.75, Unit: "mmHg", RecordedAt: time.Now().Add(-48 * time.Hour)},
+		},
+		Users: []models.User{
+			{ID: 1, Username: "admin", Password: "admin123", Role: "admin", Name: "Administrator", Email: "admin@carehub.com", Phone: "555-100-0001", Department: "Administration"},
+			{ID: 2, Username: "doctor", Password: "doctor123", Role: "doctor", Name: "Dr. Sarah Williams", Email: "sarah.williams@carehub.com", Phone: "555-100-0002", Department: "Cardiology"},
+			{ID: 3, Username: "superadmin", Password: "super123", Role: "superadmin", Name: "System Administrator", Email: "sysadmin@carehub.com", Phone: "555-100-0003", Department: "IT"},
+			{ID: 4, Username: "nurse", Password: "nurse123", Role: "nurse", Name: "Nancy White", Email: "nancy.white@carehub.com", Phone: "555-100-0004", Department: "Emergency"},
+			{ID: 5, Username: "intern", Password: "intern123", Role: "intern", Name: "Dr. Michael Lee", Email: "michael.lee@carehub.com", Phone: "555-100-0005", Department: "Pediatrics"},
+			{ID: 6, Username: "patient", Password: "patient123", Role: "patient", Name: "John Doe", Email: "john.doe@example.com", Phone: "555-123-4567", Department: ""},
+		},
+		Doctors: []models.Doctor{
+			{ID: 1, Name: "Dr. Jane Smith", Role: "doctor", Email: "jane.smith@carehub.com", Phone: "555-123-4567", Department: "Cardiology", Specialization: "Cardiology", Bio: "Dr. Smith is a board-certified cardiologist with over 15 years of experience.", Education: []string{"MD, Harvard Medical School", "Residency, Mayo Clinic"}, Experience: []string{"Senior Cardiologist, Mayo Clinic (2015-2020)", "Chief of Cardiology, CareHub Hospital (2020-Present)"}, ProfileImage: ""},
+			{ID: 2, Name: "Dr. Robert Chen", Role: "doctor", Email: "robert.chen@carehub.com", Phone: "555-234-5678", Department: "Neurology", Specialization: "Neurology", Bio: "Dr. Chen specializes in neurological disorders.", Education: []string{"MD, Johns Hopkins University", "Fellowship, Cleveland Clinic"}, Experience: []string{"Neurologist, Cleveland Clinic (2013-2018)", "Senior Neurologist, CareHub Hospital (2018-Present)"}, ProfileImage: "https://randomuser.me/api/portraits/men/32.jpg"},
+			{ID: 3, Name: "Dr. Maria Rodriguez", Role: "doctor", Email: "maria.rodriguez@carehub.com", Phone: "555-345-6789", Department: "Pediatrics", Specialization: "Pediatrics", Bio: "Dr. Rodriguez has dedicated her career to children's health.", Education: []string{"MD, Stanford University", "Residency, Children's Hospital of Philadelphia"}, Experience: []string{"Pediatrician, Boston Children's Hospital (2016-2021)", "Lead Pediatrician, CareHub Hospital (2021-Present)"}, ProfileImage: "https://randomuser.me/api/portraits/women/45.jpg"},
+			{ID: 4, Name: "Dr. James Wilson", Role: "doctor", Email: "james.wilson@carehub.com", Phone: "555-456-7890", Department: "Orthopedics", Specialization: "Orthopedic Surgery", Bio: "Dr. Wilson is an orthopedic surgeon specializing in sports injuries.", Education: []string{"MD, University of Michigan", "Orthopedic Fellowship, Hospital for Special Surgery"}, Experience: []string{"Orthopedic Surgeon, UCSF Medical Center (2012-2019)", "Chief of Orthopedics, CareHub Hospital (2019-Present)"}, ProfileImage: ""},
+		},
+		Blogs: []models.Blog{
+			{ID: 1, Title: "Heart Health Tips", Content: "Eat well, exercise, and manage stress.", Excerpt: "Stay heart-healthy!", CoverImage: "", AuthorId: 1, AuthorName: "Dr. Jane Smith", PublishedAt: "2024-06-11T11:00:00Z", UpdatedAt: "2024-06-11T14:00:00Z", Tags: []string{"cardiology"}},
+			{ID: 2, Title: "Understanding Migraines", Content: "Migraines affect millions. Here's what helps.", Excerpt: "Guide to controlling migraines.", CoverImage: "", AuthorId: 2, AuthorName: "Dr. Robert Chen", PublishedAt: "2024-05-21T09:30:00Z", UpdatedAt: "", Tags: []string{"neurology"}},
+		},
+		Interns: []models.Intern{
+			{ID: 1, Name: "Alex Green", Email: "alex.green@carehub.com", Department: "Cardiology"},
+			{ID: 2, Name: "Priya Patel", Email: "priya.patel@carehub.com", Department: "Pediatrics"},
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+)
+
+type settingsStore struct {
+	*db
+}
+
+func (s *settingsStore) Latest(_ context.Context) (models.SettingsRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.settings) == 0 {
+		return models.SettingsRecord{}, store.ErrNotFound
+	}
+	return s.settings[len(s.settings)-1], nil
+}
+
+func (s *settingsStore) Save(_ context.Context, data []byte, updatedBy int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record := models.SettingsRecord{
+		Version:   s.nextID("settings"),
+		Data:      append([]byte{}, data...),
+		UpdatedBy: &updatedBy,
+		CreatedAt: time.Now(),
+	}
+	s.settings = append(s.settings, record)
+	return record.Version, nil
+}
+
+func (s *settingsStore) History(_ context.Context) ([]models.SettingsRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	history := make([]models.SettingsRecord, 0, len(s.settings))
+	for i := len(s.settings) - 1; i >= 0; i-- {
+		history = append(history, s.settings[i])
+	}
+	return history, nil
+}
@@ -0,0 +1,150 @@
+package memory
+
+import (
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+	"context"
+)
+
+type userStore struct {
+	*db
+}
+
+// withoutPassword matches the SQL store, which never selects the password column
+func withoutPassword(u models.User) models.User {
+	u.Password = ""
+	return u
+}
+
+func (s *userStore) List(_ context.Context) ([]models.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	users := []models.User{}
+	for _, u := range sortedByID(s.users) {
+		users = append(users, withoutPassword(u))
+	}
+	return users, nil
+}
+
+func (s *userStore) Get(_ context.Context, id int) (models.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, ok := s.users[id]
+	if !ok {
+		return u, store.ErrNotFound
+	}
+	return withoutPassword(u), nil
+}
+
+func (s *userStore) GetByUsername(_ context.Context, username string) (models.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, u := range s.users {
+		if u.Username == username {
+			return u, nil
+		}
+	}
+	return models.User{}, store.ErrNotFound
+}
+
+func (s *userStore) taken(match func(models.User) bool, excludeID int) bool {
+	for _, u := range s.users {
+		if u.ID != excludeID && match(u) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *userStore) UsernameTaken(_ context.Context, username string, excludeID int) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.taken(func(u models.User) bool { return u.Username == username }, excludeID), nil
+}
+
+func (s *userStore) EmailTaken(_ context.Context, email string, excludeID int) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.taken(func(u models.User) bool { return u.Email == email }, excludeID), nil
+}
+
+// checkUnique enforces the unique indexes on users.username and users.email
+func (s *userStore) checkUnique(u *models.User) error {
+	if s.taken(func(other models.User) bool { return other.Username == u.Username }, u.ID) {
+		return duplicate("user", "username", u.Username)
+	}
+	if s.taken(func(other models.User) bool { return other.Email == u.Email }, u.ID) {
+		return duplicate("user", "email", u.Email)
+	}
+	return nil
+}
+
+func (s *userStore) Create(_ context.Context, u *models.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkUnique(u); err != nil {
+		return err
+	}
+	u.ID = s.nextID("users")
+	s.users[u.ID] = *u
+	return nil
+}
+
+func (s *userStore) Update(_ context.Context, u *models.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.users[u.ID]
+	if !ok {
+		return store.ErrNotFound
+	}
+	if err := s.checkUnique(u); err != nil {
+		return err
+	}
+
+	updated := *u
+	updated.Password = current.Password
+	s.users[u.ID] = updated
+	return nil
+}
+
+func (s *userStore) SetPassword(_ context.Context, id int, oldHash, newHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[id]
+	if !ok || (oldHash != "" && u.Password != oldHash) {
+		return nil
+	}
+	u.Password = newHash
+	s.users[id] = u
+	return nil
+}
+
+func (s *userStore) Delete(_ context.Context, id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[id]; !ok {
+		return store.ErrNotFound
+	}
+	delete(s.users, id)
+
+	// Blogs reference their author with ON DELETE CASCADE
+	for bid, b := range s.blogs {
+		if b.AuthorId == id {
+			delete(s.blogs, bid)
+		}
+	}
+	// and settings versions keep their data with updated_by SET NULL
+	for i, record := range s.settings {
+		if record.UpdatedBy != nil && *record.UpdatedBy == id {
+			s.settings[i].UpdatedBy = nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"carehub-microservice/db"
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+)
+
+// periodFormats maps AnalyticsQuery.GroupBy to a MySQL DATE_FORMAT pattern
+var periodFormats = map[string]string{
+	"day":   "%Y-%m-%d",
+	"week":  "%x-W%v",
+	"month": "%Y-%m",
+	"year":  "%Y",
+}
+
+// sqlitePeriodFormats are the strftime equivalents of periodFormats
+var sqlitePeriodFormats = map[string]string{
+	"day":   "%Y-%m-%d",
+	"week":  "%G-W%V",
+	"month": "%Y-%m",
+	"year":  "%Y",
+}
+
+// postgresPeriodFormats are the to_char equivalents of periodFormats
+var postgresPeriodFormats = map[string]string{
+	"day":   "YYYY-MM-DD",
+	"week":  `IYYY-"W"IW`,
+	"month": "YYYY-MM",
+	"year":  "YYYY",
+}
+
+type analyticsStore struct {
+	db *conn
+}
+
+// period returns an expression that formats the column col as a period label
+func (s *analyticsStore) period(col, groupBy string) (string, error) {
+	formats := periodFormats
+	switch s.db.dialect {
+	case db.SQLite:
+		formats = sqlitePeriodFormats
+	case db.Postgres:
+		formats = postgresPeriodFormats
+	}
+	format, ok := formats[groupBy]
+	if !ok {
+		return "", fmt.Errorf("unsupported grouping %q", groupBy)
+	}
+
+	// format comes from the tables above, never from user input
+	switch s.db.dialect {
+	case db.SQLite:
+		return "strftime('" + format + "', " + col + ")", nil
+	case db.Postgres:
+		return "to_char(" + col + ", '" + format + "')", nil
+	}
+	return "DATE_FORMAT(" + col + ", '" + format + "')", nil
+}
+
+// since returns a condition matching rows where col is at or after a bound
+// time. SQLite keeps times as text in more than one layout, so both sides
+// are normalised before they are compared.
+func (s *analyticsStore) since(col string) string {
+	if s.db.dialect == db.SQLite {
+		return "datetime(" + col + ") >= datetime(?)"
+	}
+	return col + " >= ?"
+}
+
+// between returns a condition matching rows where col is in [from, to)
+func (s *analyticsStore) between(col string) string {
+	if s.db.dialect == db.SQLite {
+		return "datetime(" + col + ") >= datetime(?) AND datetime(" + col + ") < datetime(?)"
+	}
+	return col + " >= ? AND " + col + " < ?"
+}
+
+// countBy runs a "label, COUNT(*)" query
+func (s *analyticsStore) countBy(ctx context.Context, query string, args ...interface{}) ([]models.LabelCount, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []models.LabelCount{}
+	for rows.Next() {
+		var label sql.NullString
+		var lc models.LabelCount
+		if err := rows.Scan(&label, &lc.Count); err != nil {
+			return nil, err
+		}
+		lc.Label = label.String
+		results = append(results, lc)
+	}
+	return results, rows.Err()
+}
+
+func (s *analyticsStore) Analytics(ctx context.Context, q store.AnalyticsQuery) (models.Analytics, error) {
+	var analytics models.Analytics
+
+	period, err := s.period("created_at", q.GroupBy)
+	if err != nil {
+		return analytics, err
+	}
+	now := time.Now()
+
+	// Summary figures are global rather than limited to the requested range
+	summary := &analytics.Summary
+	err = s.db.QueryRowContext(ctx, `SELECT
+			(SELECT COUNT(*) FROM patients),
+			(SELECT COUNT(*) FROM appointments WHERE status = 'Scheduled' AND `+s.since("date_time")+`),
+			(SELECT COUNT(*) FROM appointments WHERE status = 'Completed'),
+			(SELECT COUNT(*) FROM doctors),
+			(SELECT COUNT(*) FROM users),
+			(SELECT COUNT(*) FROM blogs),
+			(SELECT COUNT(*) FROM patients WHERE `+s.since("created_at")+`)`,
+		now, q.RecentSince).Scan(&summary.TotalPatients, &summary.ActiveAppointments,
+		&summary.CompletedAppointments, &summary.ActiveDoctors, &summary.TotalUsers,
+		&summary.TotalBlogs, &summary.RecentPatients)
+	if err != nil {
+		return analytics, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+period+` AS period, COUNT(*)
+		FROM patients WHERE `+s.between("created_at")+`
+		GROUP BY period ORDER BY period`, q.From, q.To)
+	if err != nil {
+		return analytics, err
+	}
+	defer rows.Close()
+
+	analytics.NewPatients = []models.PeriodCount{}
+	for rows.Next() {
+		var pc models.PeriodCount
+		if err := rows.Scan(&pc.Period, &pc.Count); err != nil {
+			return analytics, err
+		}
+		analytics.NewPatients = append(analytics.NewPatients, pc)
+	}
+	if err := rows.Err(); err != nil {
+		return analytics, err
+	}
+
+	queries := []struct {
+		dest  *[]models.LabelCount
+		query string
+		args  []interface{}
+	}{
+		{&analytics.AppointmentsByStatus,
+			`SELECT status, COUNT(*) FROM appointments WHERE ` + s.between("date_time") + `
+			GROUP BY status ORDER BY COUNT(*) DESC`, []interface{}{q.From, q.To}},
+		{&analytics.AppointmentsByDoctor,
+			`SELECT doctor, COUNT(*) FROM appointments WHERE ` + s.between("date_time") + `
+			GROUP BY doctor ORDER BY COUNT(*) DESC`, []interface{}{q.From, q.To}},
+		{&analytics.MetricsByType,
+			`SELECT type, COUNT(*) FROM health_metrics WHERE ` + s.between("recorded_at") + `
+			GROUP BY type ORDER BY COUNT(*) DESC`, []interface{}{q.From, q.To}},
+		{&analytics.BlogsByAuthor,
+			`SELECT author_name, COUNT(*) FROM blogs WHERE ` + s.between("published_at") + `
+			GROUP BY author_name ORDER BY COUNT(*) DESC`, []interface{}{q.From, q.To}},
+		{&analytics.UsersByRole,
+			`SELECT role, COUNT(*) FROM users GROUP BY role ORDER BY COUNT(*) DESC`, nil},
+	}
+
+	for _, query := range queries {
+		results, err := s.countBy(ctx, query.query, query.args...)
+		if err != nil {
+			return analytics, err
+		}
+		*query.dest = results
+	}
+
+	return analytics, nil
+}
+
+func (s *analyticsStore) Gauges(ctx context.Context, dayStart, dayEnd time.Time) (models.Gauges, error) {
+	var gauges models.Gauges
+	err := s.db.QueryRowContext(ctx, `SELECT
+			(SELECT COUNT(*) FROM patients),
+			(SELECT COUNT(*) FROM users),
+			(SELECT COUNT(*) FROM appointments WHERE status = 'Scheduled' AND `+s.between("date_time")+`)`,
+		dayStart, dayEnd).Scan(&gauges.Patients, &gauges.Users, &gauges.AppointmentsScheduledToday)
+	return gauges, err
+}
@@ -0,0 +1,91 @@
+package sqlstore
+
+import (
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+	"context"
+)
+
+const appointmentColumns = "id, patient_id, date_time, description, status, doctor"
+
+type appointmentStore struct {
+	db *conn
+}
+
+func scanAppointment(row scanner) (models.Appointment, error) {
+	var appointment models.Appointment
+	err := row.Scan(&appointment.ID, &appointment.PatientID, &appointment.DateTime,
+		&appointment.Description, &appointment.Status, &appointment.Doctor)
+	return appointment, err
+}
+
+func (s *appointmentStore) List(ctx context.Context, f store.AppointmentFilter, p store.Page) ([]models.Appointment, int, error) {
+	q := s.db.listQuery()
+	q.equal("patient_id", f.PatientID)
+	q.equalFold("status", f.Status)
+	q.equalFold("doctor", f.Doctor)
+	q.since("date_time", f.From)
+	q.before("date_time", f.To)
+
+	total, err := q.count(ctx, s.db, "appointments")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	clauses, args := q.page(p, map[string]string{
+		"id":        "id",
+		"patientId": "patient_id",
+		"dateTime":  q.time("date_time"),
+		"status":    "status",
+		"doctor":    "doctor",
+	})
+	rows, err := s.db.QueryContext(ctx, "SELECT "+appointmentColumns+" FROM appointments"+clauses, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	appointments := []models.Appointment{}
+	for rows.Next() {
+		appointment, err := scanAppointment(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		appointments = append(appointments, appointment)
+	}
+	return appointments, total, rows.Err()
+}
+
+func (s *appointmentStore) Get(ctx context.Context, id int) (models.Appointment, error) {
+	appointment, err := scanAppointment(s.db.QueryRowContext(ctx, "SELECT "+appointmentColumns+" FROM appointments WHERE id = ?", id))
+	return appointment, notFound(err)
+}
+
+func (s *appointmentStore) Create(ctx context.Context, a *models.Appointment) error {
+	query := `INSERT INTO appointments (patient_id, date_time, description, status, doctor) 
+			  VALUES (?, ?, ?, ?, ?)`
+	id, err := s.db.insert(ctx, "id", query, a.PatientID, a.DateTime, a.Description, a.Status, a.Doctor)
+	if err != nil {
+		return err
+	}
+	a.ID = id
+	return nil
+}
+
+func (s *appointmentStore) Update(ctx context.Context, a *models.Appointment) error {
+	query := `UPDATE appointments SET patient_id = ?, date_time = ?, description = ?,
+			 status = ?, doctor = ? WHERE id = ?`
+	result, err := s.db.ExecContext(ctx, query, a.PatientID, a.DateTime, a.Description, a.Status, a.Doctor, a.ID)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
+
+func (s *appointmentStore) Delete(ctx context.Context, id int) error {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM appointments WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
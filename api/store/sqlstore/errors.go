@@ -0,0 +1,63 @@
+package sqlstore
+
+import (
+	"errors"
+	"fmt"
+
+	"carehub-microservice/store"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// MySQL error numbers for constraint violations
+const (
+	mysqlDuplicateEntry  = 1062
+	mysqlNoReferencedRow = 1452
+)
+
+// Postgres SQLSTATE codes for constraint violations
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+// translate wraps driver errors for constraint violations in
+// store.ErrConflict or store.ErrInvalidReference, so handlers can map them to
+// responses without knowing which database is in use. The driver error is
+// kept in the chain for logging.
+func translate(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var mysqlErr *mysql.MySQLError
+	var pgErr *pgconn.PgError
+	var sqliteErr *sqlite.Error
+	switch {
+	case errors.As(err, &mysqlErr):
+		switch mysqlErr.Number {
+		case mysqlDuplicateEntry:
+			return fmt.Errorf("%w: %w", store.ErrConflict, err)
+		case mysqlNoReferencedRow:
+			return fmt.Errorf("%w: %w", store.ErrInvalidReference, err)
+		}
+	case errors.As(err, &pgErr):
+		switch pgErr.Code {
+		case pgUniqueViolation:
+			return fmt.Errorf("%w: %w", store.ErrConflict, err)
+		case pgForeignKeyViolation:
+			return fmt.Errorf("%w: %w", store.ErrInvalidReference, err)
+		}
+	case errors.As(err, &sqliteErr):
+		switch sqliteErr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+			return fmt.Errorf("%w: %w", store.ErrConflict, err)
+		case sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+			return fmt.Errorf("%w: %w", store.ErrInvalidReference, err)
+		}
+	}
+	return err
+}
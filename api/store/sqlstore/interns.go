@@ -0,0 +1,52 @@
+package sqlstore
+
+import (
+	"carehub-microservice/models"
+	"carehub-microservice/store"
+	"context"
+)
+
+type internStore struct {
+	db *conn
+}
+
+// internSortColumns maps store.InternSortKeys to columns
+var internSortColumns = map[string]string{
+	"id":         "id",
+	"name":       "name",
+	"department": "department",
+}
+
+func (s *internStore) List(ctx context.Context, f store.InternFilter, p store.Page) ([]models.Intern, int, error) {
+	q := s.db.listQuery()
+	q.equalFold("department", f.Department)
+
+	total, err := q.count(ctx, s.db, "interns")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	clauses, args := q.page(p, internSortColumns)
+	rows, err := s.db.QueryContext(ctx, "SELECT id, name, email, department FROM interns"+clauses, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	interns := []models.Intern{}
+	for rows.Next() {
+		var intern models.Intern
+		if err := rows.Scan(&intern.ID, &intern.Name, &intern.Email, &intern.Department); err != nil {
+			return nil, 0, err
+		}
+		interns = append(interns, intern)
+	}
+	return interns, total, rows.Err()
+}
+
+func (s *internStore) Get(ctx context.Context, id int) (models.Intern, error) {
+	var intern models.Intern
+	err := s.db.QueryRowContext(ctx, "SELECT id, name, email, department FROM interns WHERE id = ?", id).Scan(
+		&intern.ID, &intern.Name, &intern.Email, &intern.Department)
+	return intern, notFound(err)
+}
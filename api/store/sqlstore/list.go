@@ -0,0 +1,101 @@
+package sqlstore
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"carehub-microservice/db"
+	"carehub-microservice/store"
+)
+
+// listQuery collects the conditions of a List call and renders its WHERE,
+// ORDER BY and LIMIT clauses
+type listQuery struct {
+	dialect db.Dialect
+	conds   []string
+	args    []interface{}
+}
+
+func (c *conn) listQuery() *listQuery {
+	return &listQuery{dialect: c.dialect}
+}
+
+// equal matches rows where col is v, unless v is zero
+func (q *listQuery) equal(col string, v int) {
+	if v != 0 {
+		q.conds = append(q.conds, col+" = ?")
+		q.args = append(q.args, v)
+	}
+}
+
+// equalFold matches rows where col is v ignoring case, unless v is empty
+func (q *listQuery) equalFold(col, v string) {
+	if v != "" {
+		q.conds = append(q.conds, "LOWER("+col+") = LOWER(?)")
+		q.args = append(q.args, v)
+	}
+}
+
+// since matches rows where the time column col is at or after t, unless t
+// is zero
+func (q *listQuery) since(col string, t time.Time) {
+	if !t.IsZero() {
+		q.conds = append(q.conds, q.time(col)+" >= "+q.time("?"))
+		q.args = append(q.args, t)
+	}
+}
+
+// before matches rows where the time column col is before t, unless t is zero
+func (q *listQuery) before(col string, t time.Time) {
+	if !t.IsZero() {
+		q.conds = append(q.conds, q.time(col)+" < "+q.time("?"))
+		q.args = append(q.args, t)
+	}
+}
+
+// time returns an expression for a time column that compares and sorts
+// correctly. SQLite keeps times as text in more than one layout, so they are
+// normalised first.
+func (q *listQuery) time(col string) string {
+	if q.dialect == db.SQLite {
+		return "datetime(" + col + ")"
+	}
+	return col
+}
+
+func (q *listQuery) where() string {
+	if len(q.conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(q.conds, " AND ")
+}
+
+// page returns the WHERE, ORDER BY and LIMIT clauses for p and their
+// arguments. sortColumns maps the store's sort keys to column expressions;
+// the key itself never reaches the query.
+func (q *listQuery) page(p store.Page, sortColumns map[string]string) (string, []interface{}) {
+	direction := " ASC"
+	if p.Desc {
+		direction = " DESC"
+	}
+	order := " ORDER BY "
+	if col, ok := sortColumns[p.Sort]; ok && col != "id" {
+		order += col + direction + ", "
+	}
+	order += "id" + direction
+
+	args := append([]interface{}{}, q.args...)
+	if p.Limit > 0 {
+		order += " LIMIT ? OFFSET ?"
+		args = append(args, p.Limit, p.Offset)
+	}
+	return q.where() + order, args
+}
+
+// count returns the number of rows in table matching the conditions
+func (q *listQuery) count(ctx context.Context, c *conn, table string) (int, error) {
+	var total int
+	err := c.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table+q.where(), q.args...).Scan(&total)
+	return total, err
+}
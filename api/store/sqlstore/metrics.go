@@ -0,0 +1,43 @@
+package sqlstore
+
+import (
+	"carehub-microservice/models"
+	"context"
+)
+
+type metricStore struct {
+	db *conn
+}
+
+func (s *metricStore) ListByPatient(ctx context.Context, patientID int) ([]models.HealthMetric, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT id, patient_id, type, value, unit, recorded_at FROM health_metrics WHERE patient_id = ?", patientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var metrics []models.HealthMetric
+	for rows.Next() {
+		var metric models.HealthMetric
+		err := rows.Scan(&metric.ID, &metric.PatientID, &metric.Type,
+			&metric.Value, &metric.Unit, &metric.RecordedAt)
+		if err != nil {
+			return nil, err
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics, rows.Err()
+}
+
+func (s *metricStore) Create(ctx context.Context, m *models.HealthMetric) error {
+	query := `INSERT INTO health_metrics (patient_id, type, value, unit) 
+			  VALUES (?, ?, ?, ?)`
+	id, err := s.db.insert(ctx, "id", query, m.PatientID, m.Type, m.Value, m.Unit)
+	if err != nil {
+		return err
+	}
+
+	// Fetch the recorded_at timestamp set by the database
+	m.ID = id
+	return s.db.QueryRowContext(ctx, "SELECT recorded_at FROM health_metrics WHERE id = ?", id).Scan(&m.RecordedAt)
+}
@@ -0,0 +1,282 @@
+package sqlstore
+
+import (
+	"carehub-microservice/db"
+	"carehub-microservice/models"
+	"carehub-microservice/search"
+	"carehub-microservice/store"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+const patientColumns = "id, first_name, last_name, date_of_birth, email, phone, address, created_at"
+
+type patientStore struct {
+	db *conn
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPatient(row scanner) (models.Patient, error) {
+	var patient models.Patient
+	err := row.Scan(&patient.ID, &patient.FirstName, &patient.LastName, &patient.DateOfBirth,
+		&patient.Email, &patient.Phone, &patient.Address, &patient.CreatedAt)
+	patient.DateOfBirth = dateOnly(patient.DateOfBirth)
+	return patient, err
+}
+
+// dateOnly cuts the midnight time some drivers add when they return a DATE
+// column as a timestamp, so dates go out as the YYYY-MM-DD they came in as
+func dateOnly(value string) string {
+	if len(value) > 10 && (value[10] == 'T' || value[10] == ' ') {
+		return value[:10]
+	}
+	return value
+}
+
+// patientSortColumns maps store.PatientSortKeys to columns
+var patientSortColumns = map[string]string{
+	"id":          "id",
+	"firstName":   "first_name",
+	"lastName":    "last_name",
+	"dateOfBirth": "date_of_birth",
+	"createdAt":   "created_at",
+}
+
+func (s *patientStore) List(ctx context.Context, f store.PatientFilter, p store.Page) ([]models.Patient, int, error) {
+	q := s.db.listQuery()
+	q.equalFold("first_name", f.FirstName)
+	q.equalFold("last_name", f.LastName)
+	q.equalFold("email", f.Email)
+
+	total, err := q.count(ctx, s.db, "patients")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	clauses, args := q.page(p, patientSortColumns)
+	rows, err := s.db.QueryContext(ctx, "SELECT "+patientColumns+" FROM patients"+clauses, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	patients := []models.Patient{}
+	for rows.Next() {
+		patient, err := scanPatient(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		patients = append(patients, patient)
+	}
+	return patients, total, rows.Err()
+}
+
+// conditions is a list of SQL conditions and their arguments
+type conditions struct {
+	conds []string
+	args  []interface{}
+}
+
+func (c *conditions) add(cond string, args ...interface{}) {
+	c.conds = append(c.conds, cond)
+	c.args = append(c.args, args...)
+}
+
+// or joins the conditions so that any of them matches
+func (c *conditions) or() string {
+	return "(" + strings.Join(c.conds, ") OR (") + ")"
+}
+
+// Search fetches the strongest candidates first, so that the limit drops
+// the weakest: exact matches on any field, then name prefixes, then names
+// that only sound alike, then names that may be typos. Ties are broken by ID.
+func (s *patientStore) Search(ctx context.Context, q store.PatientSearch, limit int) ([]models.Patient, error) {
+	var exact, prefix, sound, typo conditions
+	addPrefix := func(c *conditions, expr, value string) {
+		cond, args := s.db.hasPrefix(expr, value)
+		c.add(cond, args...)
+	}
+
+	if q.Email != "" {
+		exact.add(s.db.lower("email")+" = ?", strings.ToLower(q.Email))
+	}
+	if len(q.DatesOfBirth) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(q.DatesOfBirth)), ", ")
+		dates := make([]interface{}, len(q.DatesOfBirth))
+		for i, date := range q.DatesOfBirth {
+			dates[i] = date
+		}
+		exact.add("date_of_birth IN ("+placeholders+")", dates...)
+	}
+	if q.Phone != "" {
+		addPrefix(&exact, "phone_digits", q.Phone)
+	}
+	for _, name := range q.Names {
+		for _, col := range []string{"first_name", "last_name"} {
+			exact.add(s.db.lower(col)+" = ?", name)
+			addPrefix(&prefix, s.db.lower(col), name)
+			if key := search.Soundex(name); key != "" {
+				sound.add(col+"_soundex = ?", key)
+			}
+			// Typos Soundex misses are found by scanning the names with the
+			// same first letter and a length close enough to be in reach
+			if n, k := len([]rune(name)), search.MaxTypos(name); k > 0 {
+				cond, args := s.db.hasPrefix(s.db.lower(col), string([]rune(name)[:1]))
+				typo.add(cond+" AND "+s.db.charLength(col)+" BETWEEN ? AND ?", append(args, n-k, n+k)...)
+			}
+		}
+	}
+
+	var all conditions
+	var rank strings.Builder
+	var rankArgs []interface{}
+	rank.WriteString("CASE")
+	for i, tier := range []*conditions{&exact, &prefix, &sound, &typo} {
+		if len(tier.conds) == 0 {
+			continue
+		}
+		all.conds = append(all.conds, tier.conds...)
+		all.args = append(all.args, tier.args...)
+		fmt.Fprintf(&rank, " WHEN %s THEN %d", tier.or(), i)
+		rankArgs = append(rankArgs, tier.args...)
+	}
+	rank.WriteString(" END")
+	if len(all.conds) == 0 {
+		return []models.Patient{}, nil
+	}
+
+	query := "SELECT " + patientColumns + " FROM patients WHERE " + all.or() + " ORDER BY " + rank.String() + ", id"
+	args := append(all.args, rankArgs...)
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	patients := []models.Patient{}
+	for rows.Next() {
+		patient, err := scanPatient(rows)
+		if err != nil {
+			return nil, err
+		}
+		patients = append(patients, patient)
+	}
+	return patients, rows.Err()
+}
+
+// lower returns an expression for col in lower case that the search indexes
+// cover. MySQL's collation already ignores case, so col is used as it is.
+func (c *conn) lower(col string) string {
+	if c.dialect == db.MySQL {
+		return col
+	}
+	return "LOWER(" + col + ")"
+}
+
+// charLength returns an expression for the length of col in characters
+func (c *conn) charLength(col string) string {
+	if c.dialect == db.MySQL {
+		return "CHAR_LENGTH(" + col + ")"
+	}
+	return "LENGTH(" + col + ")"
+}
+
+// hasPrefix returns a condition matching rows where expr starts with prefix,
+// written so the dialect can answer it from an index, and its arguments.
+// prefix must not contain LIKE wildcards; search terms never do.
+func (c *conn) hasPrefix(expr, prefix string) (string, []interface{}) {
+	if c.dialect == db.SQLite {
+		// Everything starting with "smi" sorts from "smi" up to "smj"
+		end := []byte(prefix)
+		end[len(end)-1]++
+		return expr + " >= ? AND " + expr + " < ?", []interface{}{prefix, string(end)}
+	}
+	return expr + " LIKE ?", []interface{}{prefix + "%"}
+}
+
+func (s *patientStore) Reindex(ctx context.Context) (int, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, first_name, last_name, phone FROM patients
+		WHERE first_name_soundex IS NULL OR last_name_soundex IS NULL OR phone_digits IS NULL`)
+	if err != nil {
+		return 0, err
+	}
+	// Read every row before updating, SQLite cannot write while a read is open
+	var patients []models.Patient
+	for rows.Next() {
+		var p models.Patient
+		var phone sql.NullString
+		if err := rows.Scan(&p.ID, &p.FirstName, &p.LastName, &phone); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		p.Phone = phone.String
+		patients = append(patients, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for i, p := range patients {
+		_, err := s.db.ExecContext(ctx, "UPDATE patients SET first_name_soundex = ?, last_name_soundex = ?, phone_digits = ? WHERE id = ?",
+			search.Soundex(p.FirstName), search.Soundex(p.LastName), search.Digits(p.Phone), p.ID)
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(patients), nil
+}
+
+func (s *patientStore) Get(ctx context.Context, id int) (models.Patient, error) {
+	patient, err := scanPatient(s.db.QueryRowContext(ctx, "SELECT "+patientColumns+" FROM patients WHERE id = ?", id))
+	return patient, notFound(err)
+}
+
+func (s *patientStore) Create(ctx context.Context, p *models.Patient) error {
+	query := `INSERT INTO patients (first_name, last_name, date_of_birth, email, phone, address,
+			  first_name_soundex, last_name_soundex, phone_digits) 
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	id, err := s.db.insert(ctx, "id", query, p.FirstName, p.LastName, p.DateOfBirth, p.Email, p.Phone, p.Address,
+		search.Soundex(p.FirstName), search.Soundex(p.LastName), search.Digits(p.Phone))
+	if err != nil {
+		return err
+	}
+
+	p.ID = id
+	return s.db.QueryRowContext(ctx, "SELECT created_at FROM patients WHERE id = ?", id).Scan(&p.CreatedAt)
+}
+
+func (s *patientStore) Update(ctx context.Context, p *models.Patient) error {
+	query := `UPDATE patients SET first_name = ?, last_name = ?, date_of_birth = ?, 
+			 email = ?, phone = ?, address = ?,
+			 first_name_soundex = ?, last_name_soundex = ?, phone_digits = ? WHERE id = ?`
+	result, err := s.db.ExecContext(ctx, query, p.FirstName, p.LastName, p.DateOfBirth,
+		p.Email, p.Phone, p.Address,
+		search.Soundex(p.FirstName), search.Soundex(p.LastName), search.Digits(p.Phone), p.ID)
+	if err != nil {
+		return err
+	}
+	if err := checkAffected(result); err != nil {
+		return err
+	}
+
+	// Fetch the created_at timestamp of the updated patient
+	return notFound(s.db.QueryRowContext(ctx, "SELECT created_at FROM patients WHERE id = ?", p.ID).Scan(&p.CreatedAt))
+}
+
+func (s *patientStore) Delete(ctx context.Context, id int) error {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM patients WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
@@ -0,0 +1,53 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+
+	"carehub-microservice/models"
+)
+
+type settingsStore struct {
+	db *conn
+}
+
+func scanSettings(row scanner) (models.SettingsRecord, error) {
+	var record models.SettingsRecord
+	var updatedBy sql.NullInt64
+	if err := row.Scan(&record.Version, &record.Data, &updatedBy, &record.CreatedAt); err != nil {
+		return record, err
+	}
+	if updatedBy.Valid {
+		id := int(updatedBy.Int64)
+		record.UpdatedBy = &id
+	}
+	return record, nil
+}
+
+func (s *settingsStore) Latest(ctx context.Context) (models.SettingsRecord, error) {
+	record, err := scanSettings(s.db.QueryRowContext(ctx,
+		"SELECT version, data, updated_by, created_at FROM system_settings ORDER BY version DESC LIMIT 1"))
+	return record, notFound(err)
+}
+
+func (s *settingsStore) Save(ctx context.Context, data []byte, updatedBy int) (int, error) {
+	return s.db.insert(ctx, "version", "INSERT INTO system_settings (data, updated_by) VALUES (?, ?)", data, updatedBy)
+}
+
+func (s *settingsStore) History(ctx context.Context) ([]models.SettingsRecord, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT version, data, updated_by, created_at FROM system_settings ORDER BY version DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []models.SettingsRecord
+	for rows.Next() {
+		record, err := scanSettings(rows)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, record)
+	}
+	return history, rows.Err()
+}
@@ -0,0 +1,105 @@
+// Package sqlstore implements the store interfaces on top of database/sql
+// for the schemas in the migrations directory. Queries are written once with
+// ? placeholders and rebound for the dialect when they run; the few that
+// cannot be shared are built by the store for the dialect it was given.
+// Every query runs under the caller's context, so cancelling it aborts the
+// query on the server, and is traced as a child span of that context.
+// Constraint violations on writes are reported as store.ErrConflict or
+// store.ErrInvalidReference whichever driver raised them.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+
+	"carehub-microservice/db"
+	"carehub-microservice/store"
+)
+
+// New returns a store.Store backed by conn, which speaks dialect
+func New(sqlDB *sql.DB, dialect db.Dialect) store.Store {
+	c := &conn{db: sqlDB, dialect: dialect}
+	return store.Store{
+		Patients:     &patientStore{db: c},
+		Appointments: &appointmentStore{db: c},
+		Metrics:      &metricStore{db: c},
+		Doctors:      &doctorStore{db: c},
+		Blogs:        &blogStore{db: c},
+		Interns:      &internStore{db: c},
+		Users:        &userStore{db: c},
+		Settings:     &settingsStore{db: c},
+		Analytics:    &analyticsStore{db: c},
+	}
+}
+
+// conn wraps a database handle, rebinding every query for its dialect and
+// tracing it
+type conn struct {
+	db      *sql.DB
+	dialect db.Dialect
+}
+
+// QueryContext traces the query up to its first result; reading the
+// remaining rows happens after the span has ended.
+func (c *conn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	query = c.dialect.Rebind(query)
+	ctx, span := c.startSpan(ctx, query)
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	endSpan(span, err)
+	return rows, err
+}
+
+func (c *conn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	query = c.dialect.Rebind(query)
+	ctx, span := c.startSpan(ctx, query)
+	row := c.db.QueryRowContext(ctx, query, args...)
+	endSpan(span, row.Err())
+	return row
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	query = c.dialect.Rebind(query)
+	ctx, span := c.startSpan(ctx, query)
+	result, err := c.db.ExecContext(ctx, query, args...)
+	endSpan(span, err)
+	return result, translate(err)
+}
+
+// insert runs an INSERT and returns the generated value of the key column.
+// Postgres drivers do not support LastInsertId, so the key is read back with
+// RETURNING there instead.
+func (c *conn) insert(ctx context.Context, key, query string, args ...interface{}) (int, error) {
+	if c.dialect == db.Postgres {
+		var id int
+		err := c.QueryRowContext(ctx, query+" RETURNING "+key, args...).Scan(&id)
+		return id, translate(err)
+	}
+
+	result, err := c.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	return int(id), err
+}
+
+// notFound maps sql.ErrNoRows to store.ErrNotFound
+func notFound(err error) error {
+	if err == sql.ErrNoRows {
+		return store.ErrNotFound
+	}
+	return err
+}
+
+// checkAffected returns store.ErrNotFound if result matched no rows. On
+// MySQL this relies on the connection reporting found rows.
+func checkAffected(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
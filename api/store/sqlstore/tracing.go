@@ -0,0 +1,82 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"carehub-microservice/db"
+	"carehub-microservice/logging"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("carehub-microservice/store/sqlstore")
+
+// dbSystems maps dialects to the OpenTelemetry db.system values
+var dbSystems = map[db.Dialect]attribute.KeyValue{
+	db.MySQL:    semconv.DBSystemMySQL,
+	db.Postgres: semconv.DBSystemPostgreSQL,
+	db.SQLite:   semconv.DBSystemSqlite,
+}
+
+// startSpan starts a client span for one statement. The statement is
+// recorded with its placeholders only; argument values can hold patient
+// data and are never attached to spans.
+func (c *conn) startSpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, spanName(query),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(dbSystems[c.dialect], semconv.DBStatement(query)))
+}
+
+// endSpan records err, if any, and ends span. sql.ErrNoRows is an expected
+// outcome rather than a failure. Error messages go through the same
+// redaction as the logs, since drivers quote offending values in them.
+func endSpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		message := logging.RedactString(err.Error())
+		span.RecordError(errors.New(message))
+		span.SetStatus(codes.Error, message)
+	}
+	span.End()
+}
+
+// spanName names a span after the statement's operation and main table,
+// such as "SELECT patients", so slow queries stand out in a trace view.
+func spanName(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "sql"
+	}
+	operation := strings.ToUpper(fields[0])
+
+	var marker string
+	switch operation {
+	case "SELECT", "DELETE":
+		marker = "FROM"
+	case "INSERT":
+		marker = "INTO"
+	case "UPDATE":
+		return operation + " " + tableName(fields[1:])
+	default:
+		return operation
+	}
+	for i, field := range fields[:len(fields)-1] {
+		if strings.EqualFold(field, marker) {
+			return operation + " " + tableName(fields[i+1:])
+		}
+	}
+	return operation
+}
+
+func tableName(fields []string) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.Trim(fields[0], "`\"();,")
+}
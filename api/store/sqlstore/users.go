@@ -0,0 +1,100 @@
+package sqlstore
+
+import (
+	"carehub-microservice/models"
+	"context"
+)
+
+// userColumns never includes the password column so it cannot leak into responses
+const userColumns = "id, username, role, name, email, phone, department"
+
+type userStore struct {
+	db *conn
+}
+
+func scanUser(row scanner) (models.User, error) {
+	var user models.User
+	err := row.Scan(&user.ID, &user.Username, &user.Role, &user.Name,
+		&user.Email, &user.Phone, &user.Department)
+	return user, err
+}
+
+func (s *userStore) List(ctx context.Context) ([]models.User, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+userColumns+" FROM users")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []models.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (s *userStore) Get(ctx context.Context, id int) (models.User, error) {
+	user, err := scanUser(s.db.QueryRowContext(ctx, "SELECT "+userColumns+" FROM users WHERE id = ?", id))
+	return user, notFound(err)
+}
+
+func (s *userStore) GetByUsername(ctx context.Context, username string) (models.User, error) {
+	var user models.User
+	query := "SELECT id, username, password, role, name, email, phone, department FROM users WHERE username = ? LIMIT 1"
+	err := s.db.QueryRowContext(ctx, query, username).Scan(
+		&user.ID, &user.Username, &user.Password, &user.Role, &user.Name, &user.Email, &user.Phone, &user.Department)
+	return user, notFound(err)
+}
+
+func (s *userStore) UsernameTaken(ctx context.Context, username string, excludeID int) (bool, error) {
+	var exists bool
+	err := s.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE username = ? AND id <> ?)",
+		username, excludeID).Scan(&exists)
+	return exists, err
+}
+
+func (s *userStore) EmailTaken(ctx context.Context, email string, excludeID int) (bool, error) {
+	var exists bool
+	err := s.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE email = ? AND id <> ?)",
+		email, excludeID).Scan(&exists)
+	return exists, err
+}
+
+func (s *userStore) Create(ctx context.Context, u *models.User) error {
+	query := `INSERT INTO users (username, password, role, name, email, phone, department) 
+			  VALUES (?, ?, ?, ?, ?, ?, ?)`
+	id, err := s.db.insert(ctx, "id", query, u.Username, u.Password, u.Role,
+		u.Name, u.Email, u.Phone, u.Department)
+	if err != nil {
+		return err
+	}
+	u.ID = id
+	return nil
+}
+
+func (s *userStore) Update(ctx context.Context, u *models.User) error {
+	query := `UPDATE users SET username = ?, role = ?, name = ?, email = ?, phone = ?, department = ? WHERE id = ?`
+	_, err := s.db.ExecContext(ctx, query, u.Username, u.Role, u.Name, u.Email, u.Phone, u.Department, u.ID)
+	return err
+}
+
+func (s *userStore) SetPassword(ctx context.Context, id int, oldHash, newHash string) error {
+	if oldHash == "" {
+		_, err := s.db.ExecContext(ctx, "UPDATE users SET password = ? WHERE id = ?", newHash, id)
+		return err
+	}
+	_, err := s.db.ExecContext(ctx, "UPDATE users SET password = ? WHERE id = ? AND password = ?", newHash, id, oldHash)
+	return err
+}
+
+func (s *userStore) Delete(ctx context.Context, id int) error {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM users WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
@@ -0,0 +1,207 @@
+// Package store defines the storage interfaces used by the HTTP handlers.
+// Each aggregate has its own interface so handlers depend only on what they
+// use and tests can substitute any of them.
+//
+// Every method takes the context of the request it serves. Implementations
+// backed by a database abort the query and return the context's error once
+// it is cancelled or its deadline passes.
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"carehub-microservice/models"
+)
+
+// ErrNotFound is returned when the requested record does not exist
+var ErrNotFound = errors.New("record not found")
+
+// ErrConflict is returned when a write would duplicate a value that must be
+// unique, such as an email address
+var ErrConflict = errors.New("conflicts with an existing record")
+
+// ErrInvalidReference is returned when a write refers to a record that does
+// not exist, such as an appointment for an unknown patient
+var ErrInvalidReference = errors.New("refers to a record that does not exist")
+
+// Page selects part of a List result. Records are ordered by Sort, one of
+// the store's sort keys (the ID when empty), reversed if Desc is set, with
+// ties broken by ID so that pages never overlap. Limit 0 means no limit.
+// List methods return the page together with the number of records matching
+// the filter across all pages.
+type Page struct {
+	Limit  int
+	Offset int
+	Sort   string
+	Desc   bool
+}
+
+// Sort keys accepted by each List method, named after the JSON fields
+var (
+	PatientSortKeys     = []string{"id", "firstName", "lastName", "dateOfBirth", "createdAt"}
+	AppointmentSortKeys = []string{"id", "patientId", "dateTime", "status", "doctor"}
+	DoctorSortKeys      = []string{"id", "name", "department", "specialization"}
+	BlogSortKeys        = []string{"id", "title", "authorName", "publishedAt"}
+	InternSortKeys      = []string{"id", "name", "department"}
+)
+
+// Filters narrow a List call. Zero fields match everything; text fields
+// match whole values, ignoring case.
+type (
+	PatientFilter struct {
+		FirstName string
+		LastName  string
+		Email     string
+	}
+
+	// AppointmentFilter matches appointments in [From, To) when those are set
+	AppointmentFilter struct {
+		PatientID int
+		Status    string
+		Doctor    string
+		From      time.Time
+		To        time.Time
+	}
+
+	DoctorFilter struct {
+		Department     string
+		Specialization string
+	}
+
+	BlogFilter struct {
+		AuthorID int
+	}
+
+	InternFilter struct {
+		Department string
+	}
+)
+
+// PatientSearch selects the candidates for a patient search. A patient is a
+// candidate if it matches any of the fields: Email ignoring case, one of the
+// YYYY-MM-DD DatesOfBirth, a phone number whose digits start with Phone, or
+// a first or last name that starts with one of the lowercase Names, has the
+// same Soundex code, or starts with the same letter and is no more than
+// search.MaxTypos letters longer or shorter. Ranking the candidates is up to
+// the caller.
+type PatientSearch struct {
+	Names        []string
+	DatesOfBirth []string
+	Phone        string
+	Email        string
+}
+
+type PatientStore interface {
+	List(ctx context.Context, f PatientFilter, p Page) ([]models.Patient, int, error)
+	// Search returns up to limit candidates for s, or all of them if limit
+	// is 0. Exact matches on any field come first, then name prefixes, then
+	// names with the same Soundex code, then possible typos, each in ID order.
+	Search(ctx context.Context, s PatientSearch, limit int) ([]models.Patient, error)
+	// Reindex fills in the search keys of patients saved without them, such
+	// as those created before the keys existed, and returns how many it
+	// updated
+	Reindex(ctx context.Context) (int, error)
+	Get(ctx context.Context, id int) (models.Patient, error)
+	// Create inserts p and sets its ID and CreatedAt
+	Create(ctx context.Context, p *models.Patient) error
+	// Update replaces the patient with p.ID and refreshes p.CreatedAt
+	Update(ctx context.Context, p *models.Patient) error
+	Delete(ctx context.Context, id int) error
+}
+
+type AppointmentStore interface {
+	List(ctx context.Context, f AppointmentFilter, p Page) ([]models.Appointment, int, error)
+	Get(ctx context.Context, id int) (models.Appointment, error)
+	// Create inserts a and sets its ID
+	Create(ctx context.Context, a *models.Appointment) error
+	Update(ctx context.Context, a *models.Appointment) error
+	Delete(ctx context.Context, id int) error
+}
+
+type MetricStore interface {
+	ListByPatient(ctx context.Context, patientID int) ([]models.HealthMetric, error)
+	// Create inserts m and sets its ID and RecordedAt
+	Create(ctx context.Context, m *models.HealthMetric) error
+}
+
+type DoctorStore interface {
+	List(ctx context.Context, f DoctorFilter, p Page) ([]models.Doctor, int, error)
+	Get(ctx context.Context, id int) (models.Doctor, error)
+	Update(ctx context.Context, d *models.Doctor) error
+}
+
+type BlogStore interface {
+	List(ctx context.Context, f BlogFilter, p Page) ([]models.Blog, int, error)
+	Get(ctx context.Context, id int) (models.Blog, error)
+	// Create inserts b and sets its ID
+	Create(ctx context.Context, b *models.Blog) error
+	// Update replaces the blog with b.ID and refreshes b.PublishedAt
+	Update(ctx context.Context, b *models.Blog) error
+	Delete(ctx context.Context, id int) error
+}
+
+type InternStore interface {
+	List(ctx context.Context, f InternFilter, p Page) ([]models.Intern, int, error)
+	Get(ctx context.Context, id int) (models.Intern, error)
+}
+
+// UserStore never returns password hashes except from GetByUsername, which
+// is needed to check credentials.
+type UserStore interface {
+	List(ctx context.Context) ([]models.User, error)
+	Get(ctx context.Context, id int) (models.User, error)
+	GetByUsername(ctx context.Context, username string) (models.User, error)
+	// UsernameTaken reports whether a user other than excludeID has username
+	UsernameTaken(ctx context.Context, username string, excludeID int) (bool, error)
+	// EmailTaken reports whether a user other than excludeID has email
+	EmailTaken(ctx context.Context, email string, excludeID int) (bool, error)
+	// Create inserts u, including u.Password which must already be hashed, and sets its ID
+	Create(ctx context.Context, u *models.User) error
+	// Update saves everything but the password
+	Update(ctx context.Context, u *models.User) error
+	// SetPassword replaces the password hash. If oldHash is not empty the
+	// update only happens while the stored value still equals it.
+	SetPassword(ctx context.Context, id int, oldHash, newHash string) error
+	Delete(ctx context.Context, id int) error
+}
+
+type SettingsStore interface {
+	// Latest returns the newest saved version, or ErrNotFound if there is none
+	Latest(ctx context.Context) (models.SettingsRecord, error)
+	// Save stores data as a new version and returns its number
+	Save(ctx context.Context, data []byte, updatedBy int) (int, error)
+	// History lists every version, newest first
+	History(ctx context.Context) ([]models.SettingsRecord, error)
+}
+
+// AnalyticsQuery selects the range [From, To) and the period used to group
+// new patients: "day", "week", "month" or "year".
+type AnalyticsQuery struct {
+	From    time.Time
+	To      time.Time
+	GroupBy string
+	// RecentSince is the cut-off for the summary's recent patient count
+	RecentSince time.Time
+}
+
+type AnalyticsStore interface {
+	Analytics(ctx context.Context, q AnalyticsQuery) (models.Analytics, error)
+	// Gauges counts patients, users and the Scheduled appointments in
+	// [dayStart, dayEnd). It is cheap enough to run on every metrics scrape.
+	Gauges(ctx context.Context, dayStart, dayEnd time.Time) (models.Gauges, error)
+}
+
+// Store bundles one implementation of every interface
+type Store struct {
+	Patients     PatientStore
+	Appointments AppointmentStore
+	Metrics      MetricStore
+	Doctors      DoctorStore
+	Blogs        BlogStore
+	Interns      InternStore
+	Users        UserStore
+	Settings     SettingsStore
+	Analytics    AnalyticsStore
+}
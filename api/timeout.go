@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// routeTimeouts maps "METHOD /route/pattern" to the deadline for routes that
+// legitimately need longer than server.queryTimeout.
+var routeTimeouts = map[string]time.Duration{
+	// Aggregates over every table in the requested range
+	"GET /api/analytics": 30 * time.Second,
+}
+
+// queryTimeoutMiddleware puts a deadline on the request context, which every
+// store call receives. Queries still running when it passes, or when the
+// client disconnects, are cancelled and the handler gets the context error.
+func queryTimeoutMiddleware(defaultTimeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout, ok := routeTimeouts[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			timeout = defaultTimeout
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"carehub-microservice/models"
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type userRequest struct {
+	Username   string `json:"username"`
+	Password   string `json:"password"`
+	Role       string `json:"role"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	Phone      string `json:"phone"`
+	Department string `json:"department"`
+}
+
+// toUser copies everything but the password, which callers must hash first
+func (r userRequest) toUser() models.User {
+	return models.User{
+		Username:   r.Username,
+		Role:       r.Role,
+		Name:       r.Name,
+		Email:      r.Email,
+		Phone:      r.Phone,
+		Department: r.Department,
+	}
+}
+
+// missingFields lists the required fields left empty. The password is only
+// required when creating an account.
+func (r userRequest) missingFields(passwordRequired bool) []fieldError {
+	required := []struct{ field, value string }{
+		{"username", r.Username},
+		{"name", r.Name},
+		{"email", r.Email},
+	}
+	if passwordRequired {
+		required = append(required, struct{ field, value string }{"password", r.Password})
+	}
+
+	var missing []fieldError
+	for _, f := range required {
+		if f.value == "" {
+			missing = append(missing, fieldError{Field: f.field, Message: "is required"})
+		}
+	}
+	return missing
+}
+
+// findUserConflict returns the name of the unique field, "username" or
+// "email", that another user (other than excludeID) already holds, or "" if
+// none does.
+func (s *server) findUserConflict(ctx context.Context, username, email string, excludeID int) (string, error) {
+	taken, err := s.store.Users.UsernameTaken(ctx, username, excludeID)
+	if err != nil {
+		return "", err
+	}
+	if taken {
+		return "username", nil
+	}
+
+	taken, err = s.store.Users.EmailTaken(ctx, email, excludeID)
+	if err != nil {
+		return "", err
+	}
+	if taken {
+		return "email", nil
+	}
+
+	return "", nil
+}
+
+// canAssignRole reports whether the caller may give an account the role.
+// Only superadmins may create or promote other superadmins.
+func canAssignRole(callerRole, role string) bool {
+	if !isAdmin(callerRole) {
+		return false
+	}
+	return role != RoleSuperAdmin || callerRole == RoleSuperAdmin
+}
+
+// --- User Handlers ---
+func (s *server) getUsers(c *gin.Context) {
+	users, err := s.store.Users.List(c.Request.Context())
+	if err != nil {
+		respondStoreError(c, err, "User")
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+func (s *server) getUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, codeInvalidRequest, "Invalid ID")
+		return
+	}
+
+	if id != c.GetInt(ctxUserID) && !isAdmin(c.GetString(ctxUserRole)) {
+		abortForbidden(c, adminRoles)
+		return
+	}
+
+	user, err := s.store.Users.Get(c.Request.Context(), id)
+	if err != nil {
+		respondStoreError(c, err, "User")
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (s *server) createUser(c *gin.Context) {
+	var userData userRequest
+	if !bindJSON(c, &userData) {
+		return
+	}
+
+	if missing := userData.missingFields(true); len(missing) > 0 {
+		respondInvalid(c, "Username, password, name and email are required", missing...)
+		return
+	}
+	if !hasRole(userData.Role, validRoles) {
+		respondInvalid(c, "Invalid role", fieldError{Field: "role", Message: "is not a valid role"})
+		return
+	}
+	if !canAssignRole(c.GetString(ctxUserRole), userData.Role) {
+		abortForbidden(c, []string{RoleSuperAdmin})
+		return
+	}
+
+	conflict, err := s.findUserConflict(c.Request.Context(), userData.Username, userData.Email, 0)
+	if err != nil {
+		respondStoreError(c, err, "User")
+		return
+	}
+	if conflict != "" {
+		respondConflict(c, "user", conflict)
+		return
+	}
+
+	if msg := s.checkPasswordPolicy(c.Request.Context(), userData.Password); msg != "" {
+		respondInvalid(c, msg, fieldError{Field: "password", Message: msg})
+		return
+	}
+
+	passwordHash, err := hashPassword(userData.Password)
+	if err != nil {
+		respondInvalid(c, "Invalid password", fieldError{Field: "password", Message: "cannot be longer than 72 bytes"})
+		return
+	}
+
+	user := userData.toUser()
+	user.Password = passwordHash
+	if err := s.store.Users.Create(c.Request.Context(), &user); err != nil {
+		respondStoreError(c, err, "User")
+		return
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
+
+func (s *server) updateUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, codeInvalidRequest, "Invalid ID")
+		return
+	}
+
+	callerRole := c.GetString(ctxUserRole)
+	if id != c.GetInt(ctxUserID) && !isAdmin(callerRole) {
+		abortForbidden(c, adminRoles)
+		return
+	}
+
+	var userData userRequest
+	if !bindJSON(c, &userData) {
+		return
+	}
+
+	if missing := userData.missingFields(false); len(missing) > 0 {
+		respondInvalid(c, "Username, name and email are required", missing...)
+		return
+	}
+
+	current, err := s.store.Users.Get(c.Request.Context(), id)
+	if err != nil {
+		respondStoreError(c, err, "User")
+		return
+	}
+
+	// Editing another account needs the right to assign its role, so that
+	// admins cannot reset a superadmin's password
+	if id != c.GetInt(ctxUserID) && !canAssignRole(callerRole, current.Role) {
+		abortForbidden(c, []string{RoleSuperAdmin})
+		return
+	}
+
+	// An omitted role leaves the current one in place; changing it is an admin action
+	if userData.Role == "" {
+		userData.Role = current.Role
+	}
+	if userData.Role != current.Role {
+		if !hasRole(userData.Role, validRoles) {
+			respondInvalid(c, "Invalid role", fieldError{Field: "role", Message: "is not a valid role"})
+			return
+		}
+		if !canAssignRole(callerRole, userData.Role) || !canAssignRole(callerRole, current.Role) {
+			abortForbidden(c, adminRoles)
+			return
+		}
+	}
+
+	conflict, err := s.findUserConflict(c.Request.Context(), userData.Username, userData.Email, id)
+	if err != nil {
+		respondStoreError(c, err, "User")
+		return
+	}
+	if conflict != "" {
+		respondConflict(c, "user", conflict)
+		return
+	}
+
+	// An empty password means "keep the current one"
+	var passwordHash string
+	if userData.Password != "" {
+		if msg := s.checkPasswordPolicy(c.Request.Context(), userData.Password); msg != "" {
+			respondInvalid(c, msg, fieldError{Field: "password", Message: msg})
+			return
+		}
+
+		passwordHash, err = hashPassword(userData.Password)
+		if err != nil {
+			respondInvalid(c, "Invalid password", fieldError{Field: "password", Message: "cannot be longer than 72 bytes"})
+			return
+		}
+	}
+
+	user := userData.toUser()
+	user.ID = id
+	if err := s.store.Users.Update(c.Request.Context(), &user); err != nil {
+		respondStoreError(c, err, "User")
+		return
+	}
+	if passwordHash != "" {
+		if err := s.store.Users.SetPassword(c.Request.Context(), id, "", passwordHash); err != nil {
+			respondStoreError(c, err, "User")
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (s *server) deleteUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, codeInvalidRequest, "Invalid ID")
+		return
+	}
+
+	if id == c.GetInt(ctxUserID) {
+		respondError(c, http.StatusBadRequest, codeInvalidRequest, "You cannot delete your own account")
+		return
+	}
+
+	user, err := s.store.Users.Get(c.Request.Context(), id)
+	if err != nil {
+		respondStoreError(c, err, "User")
+		return
+	}
+	if !canAssignRole(c.GetString(ctxUserRole), user.Role) {
+		abortForbidden(c, []string{RoleSuperAdmin})
+		return
+	}
+
+	if err := s.store.Users.Delete(c.Request.Context(), id); err != nil {
+		respondStoreError(c, err, "User")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User deleted"})
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Request types declare their rules in `binding` tags, which gin checks when
+// the body is bound. Besides the validator's built-in tags these are
+// available:
+//
+//	notblank  the string contains something other than whitespace
+//	pastdate  the string is a YYYY-MM-DD date no later than today
+var customValidations = map[string]validator.Func{
+	"notblank": func(fl validator.FieldLevel) bool {
+		return strings.TrimSpace(fl.Field().String()) != ""
+	},
+	"pastdate": func(fl validator.FieldLevel) bool {
+		date, err := time.Parse(dateLayout, fl.Field().String())
+		return err == nil && !date.After(time.Now())
+	},
+}
+
+// registerValidations adds the custom tags to gin's validator and makes it
+// report fields by their JSON names
+func registerValidations() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return fmt.Errorf("unexpected validator engine %T", binding.Validator.Engine())
+	}
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			return field.Name
+		}
+		return name
+	})
+	for tag, fn := range customValidations {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			return fmt.Errorf("failed to register %q validation: %v", tag, err)
+		}
+	}
+	return nil
+}
+
+// validationDetails describes each failed rule as a field error
+func validationDetails(errs validator.ValidationErrors) []fieldError {
+	details := make([]fieldError, 0, len(errs))
+	for _, fe := range errs {
+		// The namespace starts with the struct name, which means nothing to clients
+		_, field, _ := strings.Cut(fe.Namespace(), ".")
+		details = append(details, fieldError{Field: field, Message: validationMessage(fe)})
+	}
+	return details
+}
+
+func validationMessage(fe validator.FieldError) string {
+	param := fe.Param()
+	unit := ""
+	if fe.Kind() == reflect.String {
+		unit = " characters"
+	} else if fe.Kind() == reflect.Slice {
+		unit = " items"
+	}
+
+	switch fe.Tag() {
+	case "required", "notblank":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "url":
+		return "must be a valid URL"
+	case "max":
+		return "must be at most " + param + unit
+	case "min":
+		return "must be at least " + param + unit
+	case "gt":
+		return "must be greater than " + param
+	case "gte":
+		return "must be at least " + param
+	case "lt":
+		return "must be less than " + param
+	case "lte":
+		return "must be at most " + param
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(param, " ", ", ")
+	case "pastdate":
+		return "must be a date in YYYY-MM-DD format that is not in the future"
+	}
+	return "is invalid"
+}
+
+// contains reports whether v is one of values
+func contains[T comparable](values []T, v T) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -30,7 +30,7 @@ export default function Login() {
     } catch (error: any) {
       toast({
         title: "Login failed",
-        description: error.response?.data?.error || "Invalid username or password",
+        description: error.response?.data?.error?.message || "Invalid username or password",
         variant: "destructive",
       });
     } finally {
@@ -6,7 +6,6 @@ import { Input } from "./ui/input";
 import { Card, CardContent, CardDescription, CardFooter, CardHeader, CardTitle } from "./ui/card";
 import { useToast } from "./ui/use-toast";
 import { useNavigate } from "react-router-dom";
-import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from "./ui/select";
 
 export default function SignUp() {
   const [username, setUsername] = useState("");
@@ -14,8 +13,6 @@ export default function SignUp() {
   const [name, setName] = useState("");
   const [email, setEmail] = useState("");
   const [phone, setPhone] = useState("");
-  const [role, setRole] = useState("patient");
-  const [department, setDepartment] = useState("");
   const [loading, setLoading] = useState(false);
   const { login } = useAuth();
   const { toast } = useToast();
@@ -38,8 +35,6 @@ export default function SignUp() {
           name,
           email,
           phone,
-          role,
-          department,
         }),
       });
 
@@ -133,36 +128,6 @@ export default function SignUp() {
                 onChange={(e) => setPhone(e.target.value)}
               />
             </div>
-            <div className="space-y-2">
-              <label htmlFor="role" className="text-sm font-medium">
-                Role
-              </label>
-              <Select value={role} onValueChange={setRole}>
-                <SelectTrigger>
-                  <SelectValue placeholder="Select role" />
-                </SelectTrigger>
-                <SelectContent>
-                  <SelectItem value="patient">Patient</SelectItem>
-                  <SelectItem value="doctor">Doctor</SelectItem>
-                  <SelectItem value="nurse">Nurse</SelectItem>
-                  <SelectItem value="intern">Intern</SelectItem>
-                </SelectContent>
-              </Select>
-            </div>
-            {role !== "patient" && (
-              <div className="space-y-2">
-                <label htmlFor="department" className="text-sm font-medium">
-                  Department
-                </label>
-                <Input
-                  id="department"
-                  type="text"
-                  value={department}
-                  onChange={(e) => setDepartment(e.target.value)}
-                  required
-                />
-              </div>
-            )}
           </CardContent>
           <CardFooter className="flex flex-col gap-4">
             <Button 
@@ -30,8 +30,8 @@ interface AnalyticsData {
     totalBlogs: number;
     recentPatients: number;
   };
-  patientsByMonth: Array<{
-    month: string;
+  newPatients: Array<{
+    period: string;
     count: number;
   }>;
   appointmentsByStatus: Array<{
@@ -42,9 +42,13 @@ interface AnalyticsData {
     doctor: string;
     count: number;
   }>;
-  blogsByViews: Array<{
-    title: string;
-    views: number;
+  metricsByType: Array<{
+    type: string;
+    count: number;
+  }>;
+  blogsByAuthor: Array<{
+    author: string;
+    count: number;
   }>;
   usersByRole: Array<{
     role: string;
@@ -160,8 +164,8 @@ export default function AnalyticsDashboard() {
             </CardHeader>
             <CardContent className="pt-2">
               <ResponsiveContainer width="100%" height={350}>
-                <LineChart data={analyticsData.patientsByMonth}>
-                  <XAxis dataKey="month" />
+                <LineChart data={analyticsData.newPatients}>
+                  <XAxis dataKey="period" />
                   <YAxis />
                   <RechartsTooltip />
                   <Legend />
@@ -237,18 +241,18 @@ export default function AnalyticsDashboard() {
         <TabsContent value="blogs">
           <Card>
             <CardHeader>
-              <CardTitle>Top Blog Posts by Views</CardTitle>
+              <CardTitle>Blog Posts by Author</CardTitle>
               <CardDescription>
-                Most viewed blog posts
+                Number of blog posts published per author
               </CardDescription>
             </CardHeader>
             <CardContent>
               <ResponsiveContainer width="100%" height={350}>
-                <BarChart data={analyticsData.blogsByViews} layout="vertical">
-                  <XAxis type="number" />
-                  <YAxis dataKey="title" type="category" width={150} />
+                <BarChart data={analyticsData.blogsByAuthor} layout="vertical">
+                  <XAxis type="number" allowDecimals={false} />
+                  <YAxis dataKey="author" type="category" width={150} />
                   <RechartsTooltip />
-                  <Bar dataKey="views" fill="#82ca9d" name="Views" />
+                  <Bar dataKey="count" fill="#82ca9d" name="Posts" />
                 </BarChart>
               </ResponsiveContainer>
             </CardContent>
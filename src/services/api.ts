@@ -19,6 +19,18 @@ api.interceptors.request.use((config) => {
   return config;
 });
 
+// Send users to the unauthorized page when the API denies a call for their role,
+// mirroring what RoleBasedRoute does for client-side route checks
+api.interceptors.response.use(
+  (response) => response,
+  (error) => {
+    if (error.response?.status === 403 && error.response.data?.error?.code === 'FORBIDDEN') {
+      window.location.assign('/unauthorized');
+    }
+    return Promise.reject(error);
+  }
+);
+
 // Auth services
 export const authService = {
   login: async (username: string, password: string) => {